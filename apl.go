@@ -0,0 +1,293 @@
+package alexa
+
+import (
+	"encoding/json"
+)
+
+const (
+	// APLRenderDocumentDirectiveType sends an APL document to be rendered on a device with a screen
+	APLRenderDocumentDirectiveType DirectiveType = `Alexa.Presentation.APL.RenderDocument`
+	// APLExecuteCommandsDirectiveType sends APL commands to be run against a document rendered on the device
+	APLExecuteCommandsDirectiveType DirectiveType = `Alexa.Presentation.APL.ExecuteCommands`
+
+	// APLUserEventRequestType is sent when the user interacts with an APL document which runs the SendEvent command
+	APLUserEventRequestType RequestTypeName = `Alexa.Presentation.APL.UserEvent`
+
+	// DefaultAPLVersion is the APL version of documents created with NewAPLDocument
+	DefaultAPLVersion = `1.8`
+)
+
+// APLRenderDocumentDirective sends an APL document to be rendered on a device with a screen. It should only be included
+// in a response when the device supports the Alexa.Presentation.APL interface.
+type APLRenderDocumentDirective struct {
+	// Token identifies the document. It is reported in the APL context and must be used by APLExecuteCommandsDirective
+	// to target the document
+	Token string `json:"token"`
+
+	// Document is the APL document to render. It may be a json.RawMessage holding a document authored elsewhere, or an
+	// *APLDocument
+	Document interface{} `json:"document"`
+
+	// DataSources are the data bound to the parameters of the document's main template. It is optional
+	DataSources map[string]interface{} `json:"datasources,omitempty"`
+}
+
+// GetType returns the APLRenderDocumentDirectiveType
+func (directive *APLRenderDocumentDirective) GetType() DirectiveType {
+	return APLRenderDocumentDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the APLRenderDocumentDirective type, setting the type field
+func (directive *APLRenderDocumentDirective) MarshalJSON() ([]byte, error) {
+	type Alias APLRenderDocumentDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// APLExecuteCommandsDirective sends APL commands to be run against the document identified by Token, which must have
+// been rendered by an earlier APLRenderDocumentDirective.
+type APLExecuteCommandsDirective struct {
+	// Token is the token of the rendered document the commands run against
+	Token string `json:"token"`
+
+	// Commands are run in order
+	Commands []*APLCommand `json:"commands"`
+}
+
+// GetType returns the APLExecuteCommandsDirectiveType
+func (directive *APLExecuteCommandsDirective) GetType() DirectiveType {
+	return APLExecuteCommandsDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the APLExecuteCommandsDirective type, setting the type field
+func (directive *APLExecuteCommandsDirective) MarshalJSON() ([]byte, error) {
+	type Alias APLExecuteCommandsDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// APLDocument is an APL document built in Go. Documents authored elsewhere, such as in the authoring tool, can be
+// rendered directly as a json.RawMessage instead.
+type APLDocument struct {
+	// Type is always APL
+	Type string `json:"type"`
+
+	// Version is the version of APL the document is written for
+	Version string `json:"version"`
+
+	// Import lists the packages, such as alexa-layouts, the document uses
+	Import []*APLImport `json:"import,omitempty"`
+
+	// Resources, Styles and Layouts are optional and included as they are given
+	Resources []interface{}          `json:"resources,omitempty"`
+	Styles    map[string]interface{} `json:"styles,omitempty"`
+	Layouts   map[string]interface{} `json:"layouts,omitempty"`
+
+	// MainTemplate is the template rendered when the document is inflated
+	MainTemplate *APLTemplate `json:"mainTemplate"`
+}
+
+// NewAPLDocument returns an APLDocument for DefaultAPLVersion whose main template renders items. The main template
+// has a single parameter, payload, which is bound to the data sources of the APLRenderDocumentDirective.
+func NewAPLDocument(items ...*APLComponent) *APLDocument {
+	return &APLDocument{
+		Type:    `APL`,
+		Version: DefaultAPLVersion,
+		MainTemplate: &APLTemplate{
+			Parameters: []string{`payload`},
+			Items:      items,
+		},
+	}
+}
+
+// AddImport adds the package name at version to the imports of the document
+func (document *APLDocument) AddImport(name, version string) *APLDocument {
+	document.Import = append(document.Import, &APLImport{Name: name, Version: version})
+	return document
+}
+
+// APLImport is a package imported by an APLDocument
+type APLImport struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// APLTemplate is the main template of an APLDocument
+type APLTemplate struct {
+	// Parameters are the names the data sources are bound to
+	Parameters []string `json:"parameters,omitempty"`
+
+	// Items are the components rendered by the template
+	Items []*APLComponent `json:"items"`
+}
+
+// APLComponent is a component of an APLDocument, such as a Container, Text or Image. Properties hold the properties
+// of the component, which are marshalled alongside its type and any child items.
+type APLComponent struct {
+	// Type is the type of the component
+	Type string
+
+	// Properties are the properties of the component, such as text or width
+	Properties map[string]interface{}
+
+	// Items are the child components
+	Items []*APLComponent
+}
+
+// NewAPLComponent returns an APLComponent of type componentType
+func NewAPLComponent(componentType string) *APLComponent {
+	return &APLComponent{
+		Type:       componentType,
+		Properties: map[string]interface{}{},
+	}
+}
+
+// Set sets the property name to value
+func (component *APLComponent) Set(name string, value interface{}) *APLComponent {
+	if component.Properties == nil {
+		component.Properties = map[string]interface{}{}
+	}
+	component.Properties[name] = value
+	return component
+}
+
+// Add appends items to the child components
+func (component *APLComponent) Add(items ...*APLComponent) *APLComponent {
+	component.Items = append(component.Items, items...)
+	return component
+}
+
+// MarshalJSON implements the json.Marshaler interface for the APLComponent type, combining the type, properties and
+// child items into a single object
+func (component *APLComponent) MarshalJSON() ([]byte, error) {
+	object := aplObject(component.Type, component.Properties)
+	if len(component.Items) > 0 {
+		object[`items`] = component.Items
+	}
+	return json.Marshal(object)
+}
+
+// APLCommand is a command run by an APLExecuteCommandsDirective, such as SpeakItem or SetPage. Properties hold the
+// properties of the command, which are marshalled alongside its type.
+type APLCommand struct {
+	// Type is the type of the command
+	Type string
+
+	// Properties are the properties of the command, such as componentId
+	Properties map[string]interface{}
+}
+
+// NewAPLCommand returns an APLCommand of type commandType
+func NewAPLCommand(commandType string) *APLCommand {
+	return &APLCommand{
+		Type:       commandType,
+		Properties: map[string]interface{}{},
+	}
+}
+
+// Set sets the property name to value
+func (command *APLCommand) Set(name string, value interface{}) *APLCommand {
+	if command.Properties == nil {
+		command.Properties = map[string]interface{}{}
+	}
+	command.Properties[name] = value
+	return command
+}
+
+// MarshalJSON implements the json.Marshaler interface for the APLCommand type, combining the type and properties into
+// a single object
+func (command *APLCommand) MarshalJSON() ([]byte, error) {
+	return json.Marshal(aplObject(command.Type, command.Properties))
+}
+
+// aplObject returns a copy of properties with the type set
+func aplObject(objectType string, properties map[string]interface{}) map[string]interface{} {
+	object := make(map[string]interface{}, len(properties)+1)
+	for name, value := range properties {
+		object[name] = value
+	}
+	object[`type`] = objectType
+	return object
+}
+
+// APLUserEventRequest is sent when the user interacts with a rendered APL document which runs the SendEvent command,
+// for example by touching a TouchWrapper component.
+type APLUserEventRequest struct {
+	*BaseRequestType
+
+	// Token is the token of the document which sent the event
+	Token string `json:"token"`
+
+	// Arguments are the arguments of the SendEvent command
+	Arguments []interface{} `json:"arguments"`
+
+	// Source describes the component which sent the event
+	Source *APLUserEventSource `json:"source"`
+
+	// Components holds the values of the components named in the components property of the SendEvent command
+	Components map[string]interface{} `json:"components"`
+}
+
+// GetType returns the APLUserEventRequestType
+func (request *APLUserEventRequest) GetType() RequestTypeName {
+	return APLUserEventRequestType
+}
+
+// APLUserEventSource describes the component which sent an APLUserEventRequest
+type APLUserEventSource struct {
+	// Type is the type of the component, such as TouchWrapper
+	Type string `json:"type"`
+
+	// Handler is the name of the event handler which ran the SendEvent command, such as Press
+	Handler string `json:"handler"`
+
+	// ID is the id of the component
+	ID string `json:"id"`
+
+	// Value is the value of the component
+	Value interface{} `json:"value"`
+}
+
+// APLContext is the state of the APL document rendered on the device, included in the Alexa.Presentation.APL entry of
+// the request Context
+type APLContext struct {
+	// Token is the token of the rendered document
+	Token string `json:"token"`
+
+	// Version is the version of the APL runtime on the device
+	Version string `json:"version"`
+
+	// ComponentsVisibleOnScreen describes the components of the document which are visible
+	ComponentsVisibleOnScreen []*APLVisibleComponent `json:"componentsVisibleOnScreen"`
+}
+
+// APLVisibleComponent is a component of a rendered APL document which is visible on the screen
+type APLVisibleComponent struct {
+	// UID is the runtime identifier of the component
+	UID string `json:"uid"`
+
+	// ID is the id assigned to the component in the document, if any
+	ID string `json:"id"`
+
+	// Type is the kind of content displayed, such as text or graphic
+	Type string `json:"type"`
+
+	// Position is the position and size of the component on screen, as "widthxheight+left+top:layer"
+	Position string `json:"position"`
+
+	// Tags hold the state of the component, such as whether it is focused or checked
+	Tags map[string]interface{} `json:"tags"`
+
+	// Children are the visible child components
+	Children []*APLVisibleComponent `json:"children"`
+}
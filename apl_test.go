@@ -0,0 +1,154 @@
+package alexa
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAPLRenderDocumentDirectiveMarshal(t *testing.T) {
+	Convey(`Given a RenderDocument directive with a raw JSON document`, t, func() {
+		directive := &APLRenderDocumentDirective{
+			Token:       `menu`,
+			Document:    json.RawMessage(`{"type":"APL","version":"1.8","mainTemplate":{"items":[]}}`),
+			DataSources: map[string]interface{}{`title`: `Menu`},
+		}
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+
+			Convey(`Then there will be no error`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the document will be included as it was given`, func() {
+				So(string(data), ShouldEqual, `{"type":"Alexa.Presentation.APL.RenderDocument","token":"menu",`+
+					`"document":{"type":"APL","version":"1.8","mainTemplate":{"items":[]}},"datasources":{"title":"Menu"}}`)
+			})
+		})
+	})
+
+	Convey(`Given a RenderDocument directive with a built document`, t, func() {
+		document := NewAPLDocument(
+			NewAPLComponent(`Container`).Add(
+				NewAPLComponent(`Text`).Set(`text`, `${payload.title}`),
+			),
+		).AddImport(`alexa-layouts`, `1.2.0`)
+		directive := &APLRenderDocumentDirective{Token: `menu`, Document: document}
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+			So(err, ShouldBeNil)
+
+			Convey(`Then the components will be marshalled with their types and properties`, func() {
+				So(string(data), ShouldEqual, `{"type":"Alexa.Presentation.APL.RenderDocument","token":"menu",`+
+					`"document":{"type":"APL","version":"1.8","import":[{"name":"alexa-layouts","version":"1.2.0"}],`+
+					`"mainTemplate":{"parameters":["payload"],"items":[{"items":[{"text":"${payload.title}","type":"Text"}],`+
+					`"type":"Container"}]}}}`)
+			})
+		})
+	})
+}
+
+func TestAPLExecuteCommandsDirectiveMarshal(t *testing.T) {
+	Convey(`When I marshal an ExecuteCommands directive to JSON`, t, func() {
+		directive := &APLExecuteCommandsDirective{
+			Token:    `menu`,
+			Commands: []*APLCommand{NewAPLCommand(`SpeakItem`).Set(`componentId`, `title`)},
+		}
+		data, err := json.Marshal(directive)
+
+		Convey(`Then the token and commands will be set`, func() {
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `{"type":"Alexa.Presentation.APL.ExecuteCommands","token":"menu",`+
+				`"commands":[{"componentId":"title","type":"SpeakItem"}]}`)
+		})
+	})
+}
+
+func TestAPLUserEventRequestUnmarshal(t *testing.T) {
+	Convey(`When I unmarshal the Alexa.Presentation.APL.UserEvent JSON into a Request struct`, t, func() {
+		request := &Request{}
+		if err := json.Unmarshal(aplUserEventRequestJSON, request); err != nil {
+			panic(err)
+		}
+
+		Convey(`Then the Request type will be correct`, func() {
+			So(request.Request, ShouldHaveSameTypeAs, &APLUserEventRequest{})
+			So(request.Request.GetType(), ShouldEqual, APLUserEventRequestType)
+		})
+
+		Convey(`Then the token, arguments and source will be set`, func() {
+			event := request.Request.(*APLUserEventRequest)
+			So(event.Token, ShouldEqual, `menu`)
+			So(event.Arguments, ShouldResemble, []interface{}{`select`, `dish-2`})
+			So(event.Source, ShouldResemble, &APLUserEventSource{Type: `TouchWrapper`, Handler: `Press`, ID: `dish-2`, Value: false})
+		})
+
+		Convey(`Then the APL context will be set`, func() {
+			So(request.Context.APL, ShouldNotBeNil)
+			So(request.Context.APL.Token, ShouldEqual, `menu`)
+			So(request.Context.APL.ComponentsVisibleOnScreen, ShouldHaveLength, 1)
+			So(request.Context.APL.ComponentsVisibleOnScreen[0].Children[0].ID, ShouldEqual, `dish-2`)
+		})
+	})
+}
+
+var aplUserEventRequestJSON = []byte(`
+{
+	"version": "1.0",
+	"session": {
+		"new": false,
+		"sessionId": "amzn1.echo-api.session.1",
+		"application": {
+			"applicationId": "amzn1.ask.skill.1"
+		},
+		"user": {
+			"userId": "amzn1.ask.account.1"
+		}
+	},
+	"context": {
+		"System": {
+			"application": {
+				"applicationId": "amzn1.ask.skill.1"
+			}
+		},
+		"Alexa.Presentation.APL": {
+			"token": "menu",
+			"version": "APL_WEB_RENDERER_GANDALF",
+			"componentsVisibleOnScreen": [
+				{
+					"uid": ":1000",
+					"position": "1280x800+0+0:0",
+					"type": "mixed",
+					"tags": {"viewport": {}},
+					"children": [
+						{
+							"uid": ":1002",
+							"id": "dish-2",
+							"position": "400x100+0+100:1",
+							"type": "text",
+							"tags": {"clickable": true}
+						}
+					]
+				}
+			]
+		}
+	},
+	"request": {
+		"type": "Alexa.Presentation.APL.UserEvent",
+		"requestId": "amzn1.echo-api.request.8b2f9e4d",
+		"timestamp": "2017-08-01T15:03:44Z",
+		"locale": "en-GB",
+		"token": "menu",
+		"arguments": ["select", "dish-2"],
+		"source": {
+			"type": "TouchWrapper",
+			"handler": "Press",
+			"id": "dish-2",
+			"value": false
+		}
+	}
+}
+`)
@@ -0,0 +1,274 @@
+package alexa
+
+import (
+	"encoding/json"
+)
+
+// AudioPlayerErrorType indicates the type of error that occurred during audio playback
+type AudioPlayerErrorType string
+
+// ClearBehavior indicates which streams are cleared by an AudioPlayerClearQueueDirective
+type ClearBehavior string
+
+// PlayBehavior indicates how an AudioPlayerPlayDirective affects the queue of streams
+type PlayBehavior string
+
+const (
+	// AudioPlayerPlaybackStartedRequestType indicates that Alexa has begun playing the audio stream previously sent in
+	// a Play directive
+	AudioPlayerPlaybackStartedRequestType RequestTypeName = `AudioPlayer.PlaybackStarted`
+	// AudioPlayerPlaybackFinishedRequestType indicates that the stream played by a Play directive finished playing
+	AudioPlayerPlaybackFinishedRequestType RequestTypeName = `AudioPlayer.PlaybackFinished`
+	// AudioPlayerPlaybackStoppedRequestType indicates that the stream was stopped by a Stop or ClearQueue directive,
+	// or by the user
+	AudioPlayerPlaybackStoppedRequestType RequestTypeName = `AudioPlayer.PlaybackStopped`
+	// AudioPlayerPlaybackNearlyFinishedRequestType indicates that the currently playing stream is nearly complete and
+	// the device is ready to receive a new stream
+	AudioPlayerPlaybackNearlyFinishedRequestType RequestTypeName = `AudioPlayer.PlaybackNearlyFinished`
+	// AudioPlayerPlaybackFailedRequestType indicates that Alexa encountered an error when attempting to play a stream
+	AudioPlayerPlaybackFailedRequestType RequestTypeName = `AudioPlayer.PlaybackFailed`
+
+	// AudioPlayerErrorTypeUnknown indicates that an unknown error occurred
+	AudioPlayerErrorTypeUnknown AudioPlayerErrorType = "MEDIA_ERROR_UNKNOWN"
+	// AudioPlayerErrorTypeInvalidRequest indicates that the server recognized the request as being malformed, e.g.
+	// bad request, unauthorized, forbidden or not found
+	AudioPlayerErrorTypeInvalidRequest AudioPlayerErrorType = "MEDIA_ERROR_INVALID_REQUEST"
+	// AudioPlayerErrorTypeServiceUnavailable indicates that the device was unable to reach the service
+	AudioPlayerErrorTypeServiceUnavailable AudioPlayerErrorType = "MEDIA_ERROR_SERVICE_UNAVAILABLE"
+	// AudioPlayerErrorTypeInternalServerError indicates that the server accepted the request but was unable to process
+	// it as expected
+	AudioPlayerErrorTypeInternalServerError AudioPlayerErrorType = "MEDIA_ERROR_INTERNAL_SERVER_ERROR"
+	// AudioPlayerErrorTypeInternalDeviceError indicates that there was an internal error on the device
+	AudioPlayerErrorTypeInternalDeviceError AudioPlayerErrorType = "MEDIA_ERROR_INTERNAL_DEVICE_ERROR"
+
+	// AudioPlayerPlayDirectiveType sends the device a command to stream the audio file identified by the URL
+	AudioPlayerPlayDirectiveType DirectiveType = `AudioPlayer.Play`
+	// AudioPlayerStopDirectiveType stops the current audio playback
+	AudioPlayerStopDirectiveType DirectiveType = `AudioPlayer.Stop`
+	// AudioPlayerClearQueueDirectiveType clears the audio playback queue
+	AudioPlayerClearQueueDirectiveType DirectiveType = `AudioPlayer.ClearQueue`
+
+	// ClearBehaviorClearEnqueued clears the queue and continues to play the currently playing stream
+	ClearBehaviorClearEnqueued ClearBehavior = "CLEAR_ENQUEUED"
+	// ClearBehaviorClearAll clears the entire playback queue and stops the currently playing stream
+	ClearBehaviorClearAll ClearBehavior = "CLEAR_ALL"
+
+	// PlayBehaviorReplaceAll immediately begins playback of the specified stream, and replaces the current and
+	// enqueued streams
+	PlayBehaviorReplaceAll PlayBehavior = "REPLACE_ALL"
+	// PlayBehaviorEnqueue adds the specified stream to the end of the current queue. This does not impact the currently
+	// playing stream
+	PlayBehaviorEnqueue PlayBehavior = "ENQUEUE"
+	// PlayBehaviorReplaceEnqueued replaces all streams in the queue. This does not impact the currently playing stream
+	PlayBehaviorReplaceEnqueued PlayBehavior = "REPLACE_ENQUEUED"
+)
+
+// AudioPlayerPlayback contains the fields describing the audio stream that are common to AudioPlayer requests
+type AudioPlayerPlayback struct {
+	// Token represents the audio stream. This token is provided when sending the Play directive.
+	Token string `json:"token"`
+
+	// OffsetInMilliseconds identifies the track’s offset in milliseconds at the time the request was sent.
+	OffsetInMilliseconds int64 `json:"offsetInMilliseconds"`
+}
+
+// AudioPlayerPlaybackStartedRequest is sent when Alexa begins playing the audio stream previously sent in a Play
+// directive. This lets your skill verify that playback began successfully.
+//
+// NOTE: AudioPlayer requests are not sent in the context of a session, so Request.Session is nil. Your skill can
+// respond with a Stop or ClearQueue directive, but cannot include output speech, a card or a reprompt.
+type AudioPlayerPlaybackStartedRequest struct {
+	*BaseRequestType
+	AudioPlayerPlayback
+}
+
+// GetType returns the AudioPlayerPlaybackStartedRequestType
+func (request *AudioPlayerPlaybackStartedRequest) GetType() RequestTypeName {
+	return AudioPlayerPlaybackStartedRequestType
+}
+
+// AudioPlayerPlaybackFinishedRequest is sent when the stream Alexa is playing comes to an end on its own.
+//
+// NOTE: AudioPlayer requests are not sent in the context of a session, so Request.Session is nil.
+type AudioPlayerPlaybackFinishedRequest struct {
+	*BaseRequestType
+	AudioPlayerPlayback
+}
+
+// GetType returns the AudioPlayerPlaybackFinishedRequestType
+func (request *AudioPlayerPlaybackFinishedRequest) GetType() RequestTypeName {
+	return AudioPlayerPlaybackFinishedRequestType
+}
+
+// AudioPlayerPlaybackStoppedRequest is sent when Alexa stops playing an audio stream in response to a voice request or
+// an AudioPlayer directive.
+//
+// NOTE: AudioPlayer requests are not sent in the context of a session, so Request.Session is nil. Your skill cannot
+// return a response to AudioPlayerPlaybackStoppedRequest.
+type AudioPlayerPlaybackStoppedRequest struct {
+	*BaseRequestType
+	AudioPlayerPlayback
+}
+
+// GetType returns the AudioPlayerPlaybackStoppedRequestType
+func (request *AudioPlayerPlaybackStoppedRequest) GetType() RequestTypeName {
+	return AudioPlayerPlaybackStoppedRequestType
+}
+
+// AudioPlayerPlaybackNearlyFinishedRequest is sent when the currently playing stream is nearly complete and the device
+// is ready to receive a new stream. To progress through a playlist, respond with a Play directive for the next stream
+// using the ENQUEUE play behavior.
+//
+// NOTE: AudioPlayer requests are not sent in the context of a session, so Request.Session is nil.
+type AudioPlayerPlaybackNearlyFinishedRequest struct {
+	*BaseRequestType
+	AudioPlayerPlayback
+}
+
+// GetType returns the AudioPlayerPlaybackNearlyFinishedRequestType
+func (request *AudioPlayerPlaybackNearlyFinishedRequest) GetType() RequestTypeName {
+	return AudioPlayerPlaybackNearlyFinishedRequestType
+}
+
+// AudioPlayerError provides more information about an error that occurred during audio playback
+type AudioPlayerError struct {
+	// Type indicates the type of error that occurred
+	Type AudioPlayerErrorType `json:"type"`
+
+	// Message is a description of the error the device has encountered
+	Message string `json:"message"`
+}
+
+// AudioPlayerPlaybackFailedRequest is sent when Alexa encounters an error when attempting to play a stream.
+//
+// NOTE: AudioPlayer requests are not sent in the context of a session, so Request.Session is nil.
+type AudioPlayerPlaybackFailedRequest struct {
+	*BaseRequestType
+
+	// Token represents the stream that failed to play
+	Token string `json:"token"`
+
+	// Error provides more information about the error that occurred
+	Error *AudioPlayerError `json:"error"`
+
+	// CurrentPlaybackState provides details about the playback activity occurring at the time of the error. This may
+	// describe a different stream to the one that failed to play.
+	CurrentPlaybackState *AudioPlayer `json:"currentPlaybackState"`
+}
+
+// GetType returns the AudioPlayerPlaybackFailedRequestType
+func (request *AudioPlayerPlaybackFailedRequest) GetType() RequestTypeName {
+	return AudioPlayerPlaybackFailedRequestType
+}
+
+// AudioItem contains the stream to play in an AudioPlayerPlayDirective, and the metadata to display while it plays
+type AudioItem struct {
+	// Stream describes the audio stream to play
+	Stream *AudioStream `json:"stream"`
+
+	// Metadata contains the information to display on devices with a screen while the stream plays. It is optional
+	Metadata *AudioMetadata `json:"metadata,omitempty"`
+}
+
+// AudioStream describes an audio stream to play
+type AudioStream struct {
+	// URL identifies the location of the audio content. It must be a HTTPS URL, and the content must be in a
+	// supported format
+	URL string `json:"url"`
+
+	// Token identifies the stream. It is sent back to the skill in AudioPlayer requests and the AudioPlayer context. It
+	// cannot exceed 1024 characters
+	Token string `json:"token"`
+
+	// ExpectedPreviousToken is the token of the stream expected to play before this one. It is required, and only
+	// allowed, when the PlayBehavior is PlayBehaviorEnqueue
+	ExpectedPreviousToken string `json:"expectedPreviousToken,omitempty"`
+
+	// OffsetInMilliseconds is the timestamp in the stream from which Alexa should begin playback. Set to 0 to start
+	// playing the stream from the beginning
+	OffsetInMilliseconds int64 `json:"offsetInMilliseconds"`
+}
+
+// AudioMetadata contains the information displayed on devices with a screen while an audio stream plays
+type AudioMetadata struct {
+	// Title is the title text to display
+	Title string `json:"title,omitempty"`
+
+	// Subtitle is the subtitle text to display
+	Subtitle string `json:"subtitle,omitempty"`
+
+	// Art is the image to display with the audio, such as album art
+	Art *Image `json:"art,omitempty"`
+
+	// BackgroundImage is the image to display in the background
+	BackgroundImage *Image `json:"backgroundImage,omitempty"`
+}
+
+// AudioPlayerPlayDirective sends the device a command to stream the audio file identified by the URL.
+//
+// When including this directive in a response to a LaunchRequest or IntentRequest, the ShouldEndSession field of the
+// ResponseData should be set to true to end the session.
+type AudioPlayerPlayDirective struct {
+	// PlayBehavior describes playback behavior when the stream is received
+	PlayBehavior PlayBehavior `json:"playBehavior"`
+
+	// AudioItem contains the stream to play
+	AudioItem *AudioItem `json:"audioItem"`
+}
+
+// GetType returns the AudioPlayerPlayDirectiveType
+func (directive *AudioPlayerPlayDirective) GetType() DirectiveType {
+	return AudioPlayerPlayDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the AudioPlayerPlayDirective type, setting the type field
+func (directive *AudioPlayerPlayDirective) MarshalJSON() ([]byte, error) {
+	type Alias AudioPlayerPlayDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// AudioPlayerStopDirective stops the current audio playback
+type AudioPlayerStopDirective struct{}
+
+// GetType returns the AudioPlayerStopDirectiveType
+func (directive *AudioPlayerStopDirective) GetType() DirectiveType {
+	return AudioPlayerStopDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the AudioPlayerStopDirective type, setting the type field
+func (directive *AudioPlayerStopDirective) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+	}{directive.GetType()})
+}
+
+// AudioPlayerClearQueueDirective clears the audio playback queue. You can set this directive to clear the queue
+// without stopping the currently playing stream, or clear the queue and stop any currently playing stream.
+type AudioPlayerClearQueueDirective struct {
+	// ClearBehavior describes the clear queue behavior
+	ClearBehavior ClearBehavior `json:"clearBehavior"`
+}
+
+// GetType returns the AudioPlayerClearQueueDirectiveType
+func (directive *AudioPlayerClearQueueDirective) GetType() DirectiveType {
+	return AudioPlayerClearQueueDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the AudioPlayerClearQueueDirective type, setting the type
+// field
+func (directive *AudioPlayerClearQueueDirective) MarshalJSON() ([]byte, error) {
+	type Alias AudioPlayerClearQueueDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
@@ -0,0 +1,253 @@
+package alexa
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAudioPlayerRequestUnmarshal(t *testing.T) {
+	requests := map[RequestTypeName]RequestType{
+		AudioPlayerPlaybackStartedRequestType:        &AudioPlayerPlaybackStartedRequest{},
+		AudioPlayerPlaybackFinishedRequestType:       &AudioPlayerPlaybackFinishedRequest{},
+		AudioPlayerPlaybackStoppedRequestType:        &AudioPlayerPlaybackStoppedRequest{},
+		AudioPlayerPlaybackNearlyFinishedRequestType: &AudioPlayerPlaybackNearlyFinishedRequest{},
+	}
+
+	for requestType, expected := range requests {
+		Convey(`When I unmarshal the `+string(requestType)+` JSON into a Request struct`, t, func() {
+			request := &Request{}
+			if err := json.Unmarshal(audioPlayerRequestJSON(requestType), request); err != nil {
+				panic(err)
+			}
+
+			Convey(`Then the Request type will be correct`, func() {
+				So(request.Request, ShouldHaveSameTypeAs, expected)
+				So(request.Request.GetType(), ShouldEqual, requestType)
+			})
+
+			Convey(`Then the Session will be nil`, func() {
+				So(request.Session, ShouldBeNil)
+			})
+
+			Convey(`Then the Request ID will be set correctly`, func() {
+				So(request.Request.GetID(), ShouldEqual, `amzn1.echo-api.request.5a7a9d9c`)
+			})
+
+			Convey(`Then the token and offset will be set correctly`, func() {
+				var playback AudioPlayerPlayback
+				switch audioRequest := request.Request.(type) {
+				case *AudioPlayerPlaybackStartedRequest:
+					playback = audioRequest.AudioPlayerPlayback
+				case *AudioPlayerPlaybackFinishedRequest:
+					playback = audioRequest.AudioPlayerPlayback
+				case *AudioPlayerPlaybackStoppedRequest:
+					playback = audioRequest.AudioPlayerPlayback
+				case *AudioPlayerPlaybackNearlyFinishedRequest:
+					playback = audioRequest.AudioPlayerPlayback
+				}
+				So(playback.Token, ShouldEqual, `track-1`)
+				So(playback.OffsetInMilliseconds, ShouldEqual, 2500)
+			})
+		})
+	}
+
+	Convey(`When I unmarshal the AudioPlayer.PlaybackFailed JSON into a Request struct`, t, func() {
+		request := &Request{}
+		if err := json.Unmarshal(audioPlayerPlaybackFailedRequestJSON, request); err != nil {
+			panic(err)
+		}
+
+		Convey(`Then the Request type will be a AudioPlayerPlaybackFailedRequest struct`, func() {
+			So(request.Request, ShouldHaveSameTypeAs, &AudioPlayerPlaybackFailedRequest{})
+		})
+
+		failed := request.Request.(*AudioPlayerPlaybackFailedRequest)
+
+		Convey(`Then the token will be set correctly`, func() {
+			So(failed.Token, ShouldEqual, `track-2`)
+		})
+
+		Convey(`Then the error will be set correctly`, func() {
+			So(failed.Error.Type, ShouldEqual, AudioPlayerErrorTypeServiceUnavailable)
+			So(failed.Error.Message, ShouldEqual, `Unable to reach the stream`)
+		})
+
+		Convey(`Then the current playback state will be set correctly`, func() {
+			So(failed.CurrentPlaybackState.Token, ShouldEqual, `track-1`)
+			So(failed.CurrentPlaybackState.OffsetInMilliseconds, ShouldEqual, 1000)
+			So(failed.CurrentPlaybackState.ActivityState, ShouldEqual, AudioPlayerPlayingState)
+		})
+	})
+
+	Convey(`When I unmarshal a Request with an unknown request type`, t, func() {
+		body := `{"type":"Unknown.Request","requestId":"1","locale":"en-GB","extra":true}`
+		request := &Request{}
+		err := json.Unmarshal([]byte(`{"version":"1.0","request":`+body+`}`), request)
+
+		Convey(`Then the error will be nil`, func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey(`Then the Request will be an UnknownRequest with the request type`, func() {
+			So(request.Request, ShouldHaveSameTypeAs, &UnknownRequest{})
+			So(request.Request.GetType(), ShouldEqual, `Unknown.Request`)
+		})
+
+		Convey(`Then the common request fields will be set correctly`, func() {
+			So(request.Request.GetID(), ShouldEqual, `1`)
+			So(request.Request.GetLocale(), ShouldEqual, `en-GB`)
+		})
+
+		Convey(`Then the raw request will be kept`, func() {
+			So(string(request.Request.(*UnknownRequest).Raw), ShouldEqual, body)
+		})
+	})
+}
+
+func TestAudioPlayerDirective_MarshalJSON(t *testing.T) {
+	Convey(`Given I have an AudioPlayerPlayDirective with metadata`, t, func() {
+		directive := &AudioPlayerPlayDirective{
+			PlayBehavior: PlayBehaviorEnqueue,
+			AudioItem: &AudioItem{
+				Stream: &AudioStream{
+					URL:                   `https://example.com/track-2.mp3`,
+					Token:                 `track-2`,
+					ExpectedPreviousToken: `track-1`,
+					OffsetInMilliseconds:  0,
+				},
+				Metadata: &AudioMetadata{
+					Title:    `Track 2`,
+					Subtitle: `Album`,
+					Art: &Image{
+						ContentDescription: `Album art`,
+						Sources:            []*ImageSource{{URL: `https://example.com/art.png`}},
+					},
+					BackgroundImage: &Image{
+						Sources: []*ImageSource{{URL: `https://example.com/background.png`, Size: ImageSizeLarge}},
+					},
+				},
+			},
+		}
+
+		Convey(`When I marshal it to JSON`, func() {
+			output, err := json.Marshal(directive)
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the resulting JSON will be correct`, func() {
+				So(string(output), ShouldEqual, `{"type":"AudioPlayer.Play","playBehavior":"ENQUEUE","audioItem":{"stream":`+
+					`{"url":"https://example.com/track-2.mp3","token":"track-2","expectedPreviousToken":"track-1",`+
+					`"offsetInMilliseconds":0},"metadata":{"title":"Track 2","subtitle":"Album","art":`+
+					`{"contentDescription":"Album art","sources":[{"url":"https://example.com/art.png"}]},`+
+					`"backgroundImage":{"sources":[{"url":"https://example.com/background.png","size":"LARGE"}]}}}}`)
+			})
+		})
+	})
+
+	Convey(`Given I have an AudioPlayerStopDirective`, t, func() {
+		directive := &AudioPlayerStopDirective{}
+
+		Convey(`When I marshal it to JSON`, func() {
+			output, err := json.Marshal(directive)
+			if err != nil {
+				panic(err)
+			}
+
+			Convey(`Then the resulting JSON will be correct`, func() {
+				So(string(output), ShouldEqual, `{"type":"AudioPlayer.Stop"}`)
+			})
+		})
+	})
+
+	Convey(`Given I have an AudioPlayerClearQueueDirective`, t, func() {
+		directive := &AudioPlayerClearQueueDirective{ClearBehavior: ClearBehaviorClearAll}
+
+		Convey(`When I marshal it to JSON`, func() {
+			output, err := json.Marshal(directive)
+			if err != nil {
+				panic(err)
+			}
+
+			Convey(`Then the resulting JSON will be correct`, func() {
+				So(string(output), ShouldEqual, `{"type":"AudioPlayer.ClearQueue","clearBehavior":"CLEAR_ALL"}`)
+			})
+		})
+	})
+
+	Convey(`Given I have ResponseData containing AudioPlayer directives`, t, func() {
+		data := &ResponseData{
+			ShouldEndSession: Bool(true),
+			Directives: []Directive{
+				&AudioPlayerClearQueueDirective{ClearBehavior: ClearBehaviorClearEnqueued},
+				&AudioPlayerStopDirective{},
+			},
+		}
+
+		Convey(`When I marshal it to JSON`, func() {
+			output, err := json.Marshal(data)
+			if err != nil {
+				panic(err)
+			}
+
+			Convey(`Then the directives will be marshalled into the directives array`, func() {
+				So(string(output), ShouldContainSubstring, `"directives":[{"type":"AudioPlayer.ClearQueue",`+
+					`"clearBehavior":"CLEAR_ENQUEUED"},{"type":"AudioPlayer.Stop"}]`)
+			})
+		})
+	})
+}
+
+// audioPlayerRequestJSON returns the JSON for an AudioPlayer request of the given type
+func audioPlayerRequestJSON(requestType RequestTypeName) []byte {
+	return []byte(`
+{
+	"version": "1.0",
+	"context": {
+		"System": {
+			"application": {
+				"applicationId": "amzn1.ask.skill.1"
+			},
+			"device": {
+				"supportedInterfaces": {
+					"AudioPlayer": {}
+				}
+			}
+		}
+	},
+	"request": {
+		"type": "` + string(requestType) + `",
+		"requestId": "amzn1.echo-api.request.5a7a9d9c",
+		"timestamp": "2017-08-01T15:03:44Z",
+		"locale": "en-GB",
+		"token": "track-1",
+		"offsetInMilliseconds": 2500
+	}
+}
+`)
+}
+
+var audioPlayerPlaybackFailedRequestJSON = []byte(`
+{
+	"version": "1.0",
+	"request": {
+		"type": "AudioPlayer.PlaybackFailed",
+		"requestId": "amzn1.echo-api.request.5a7a9d9d",
+		"timestamp": "2017-08-01T15:03:44Z",
+		"locale": "en-GB",
+		"token": "track-2",
+		"error": {
+			"type": "MEDIA_ERROR_SERVICE_UNAVAILABLE",
+			"message": "Unable to reach the stream"
+		},
+		"currentPlaybackState": {
+			"token": "track-1",
+			"offsetInMilliseconds": 1000,
+			"playerActivity": "PLAYING"
+		}
+	}
+}
+`)
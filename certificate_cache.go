@@ -0,0 +1,203 @@
+package alexa
+
+import (
+	"container/list"
+	"crypto/x509"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCertificateCacheSize is the maximum number of certificate chains held by the certificate cache of a Verifier
+// created by NewVerifier
+const DefaultCertificateCacheSize = 32
+
+// CertificateCache stores signing certificate chains keyed by the normalised SignatureCertChainUrl they were downloaded
+// from. Only chains which have passed VerifyCertificateChain should be stored, as cached chains are not re-verified.
+//
+// Implementations must be safe for concurrent use.
+type CertificateCache interface {
+	// Get returns the certificate chain stored for certificateURL. The boolean is false if no chain is stored or the
+	// stored chain can no longer be used.
+	Get(certificateURL string) ([]*x509.Certificate, bool)
+
+	// Set stores the certificate chain for certificateURL
+	Set(certificateURL string, certs []*x509.Certificate)
+}
+
+// certificateCacheEntry is an item held in a MemoryCertificateCache
+type certificateCacheEntry struct {
+	key   string
+	certs []*x509.Certificate
+}
+
+// MemoryCertificateCache is an in memory CertificateCache holding a bounded number of certificate chains. When full,
+// the least recently used chain is evicted. A chain is dropped from the cache once any certificate in it has expired.
+type MemoryCertificateCache struct {
+	// Now is the function used to determine whether a certificate chain has expired. If nil, time.Now is used.
+	Now func() time.Time
+
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+// NewMemoryCertificateCache returns a MemoryCertificateCache holding at most maxEntries certificate chains. If
+// maxEntries is less than 1, DefaultCertificateCacheSize is used.
+func NewMemoryCertificateCache(maxEntries int) *MemoryCertificateCache {
+	if maxEntries < 1 {
+		maxEntries = DefaultCertificateCacheSize
+	}
+	return &MemoryCertificateCache{
+		maxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+// Get implements the CertificateCache interface for the MemoryCertificateCache type
+func (cache *MemoryCertificateCache) Get(certificateURL string) ([]*x509.Certificate, bool) {
+	key := normaliseCertificateURL(certificateURL)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, found := cache.entries[key]
+	if !found {
+		return nil, false
+	}
+
+	now := time.Now
+	if cache.Now != nil {
+		now = cache.Now
+	}
+
+	entry := element.Value.(*certificateCacheEntry)
+	if len(entry.certs) == 0 || now().After(certificateChainNotAfter(entry.certs)) {
+		cache.order.Remove(element)
+		delete(cache.entries, key)
+		return nil, false
+	}
+
+	cache.order.MoveToFront(element)
+	return entry.certs, true
+}
+
+// Set implements the CertificateCache interface for the MemoryCertificateCache type
+func (cache *MemoryCertificateCache) Set(certificateURL string, certs []*x509.Certificate) {
+	key := normaliseCertificateURL(certificateURL)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if element, found := cache.entries[key]; found {
+		element.Value.(*certificateCacheEntry).certs = certs
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.entries[key] = cache.order.PushFront(&certificateCacheEntry{key: key, certs: certs})
+	for cache.order.Len() > cache.maxEntries {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*certificateCacheEntry).key)
+	}
+}
+
+// certificateChainNotAfter returns the earliest NotAfter time of the certificates in certs, after which the chain is no
+// longer valid
+func certificateChainNotAfter(certs []*x509.Certificate) time.Time {
+	notAfter := certs[0].NotAfter
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(notAfter) {
+			notAfter = cert.NotAfter
+		}
+	}
+	return notAfter
+}
+
+// Len returns the number of certificate chains currently held in the cache
+func (cache *MemoryCertificateCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
+
+// certificateCall is an in-flight or completed download of a certificate chain, and the number of callers waiting for
+// its result
+type certificateCall struct {
+	wait    sync.WaitGroup
+	waiters int
+	certs   []*x509.Certificate
+	err     error
+}
+
+// certificateLoader de-duplicates concurrent downloads of the same certificate chain so that only one download is
+// made, with every caller receiving its result
+type certificateLoader struct {
+	mutex sync.Mutex
+	calls map[string]*certificateCall
+}
+
+// load calls fn for key, unless a call for key is already in flight in which case it waits for and returns the result
+// of that call
+func (loader *certificateLoader) load(key string, fn func() ([]*x509.Certificate, error)) ([]*x509.Certificate, error) {
+	loader.mutex.Lock()
+	if loader.calls == nil {
+		loader.calls = map[string]*certificateCall{}
+	}
+	if call, found := loader.calls[key]; found {
+		call.waiters++
+		loader.mutex.Unlock()
+		call.wait.Wait()
+		return call.certs, call.err
+	}
+	call := &certificateCall{}
+	call.wait.Add(1)
+	loader.calls[key] = call
+	loader.mutex.Unlock()
+
+	call.certs, call.err = fn()
+	call.wait.Done()
+
+	loader.mutex.Lock()
+	delete(loader.calls, key)
+	loader.mutex.Unlock()
+
+	return call.certs, call.err
+}
+
+// normaliseCertificateURL returns a canonical form of a SignatureCertChainUrl for use as a cache key. The scheme and
+// host are lower cased, the default HTTPS port is removed and the path has its dot segments collapsed. If the URL
+// cannot be parsed it is returned unchanged.
+func normaliseCertificateURL(certificateURL string) string {
+	parsed, err := url.Parse(certificateURL)
+	if err != nil {
+		return certificateURL
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	if parsed.Port() == "443" {
+		parsed.Host = parsed.Hostname()
+	}
+	parsed.Path = normaliseURLPath(parsed.Path)
+	parsed.RawPath = ""
+	return parsed.String()
+}
+
+// normaliseURLPath collapses the dot segments and repeated slashes in a URL path, preserving any trailing slash. An
+// empty path is returned unchanged.
+func normaliseURLPath(urlPath string) string {
+	if urlPath == "" {
+		return urlPath
+	}
+	cleaned := path.Clean("/" + urlPath)
+	if strings.HasSuffix(urlPath, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
@@ -0,0 +1,311 @@
+package alexa
+
+import (
+	"crypto"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryCertificateCache(t *testing.T) {
+	now := time.Now()
+	certificateURL := `https://s3.amazonaws.com/echo.api/echo-api-cert.pem`
+
+	Convey(`Given I have an empty MemoryCertificateCache`, t, func() {
+		cache := NewMemoryCertificateCache(2)
+
+		Convey(`When I get a certificate chain`, func() {
+			certs, found := cache.Get(certificateURL)
+
+			Convey(`Then the chain will not be found`, func() {
+				So(found, ShouldBeFalse)
+				So(certs, ShouldBeNil)
+			})
+		})
+
+		Convey(`And I set a valid certificate chain`, func() {
+			chain := newTestCertificateChain(now.Add(-1*time.Hour), now.Add(time.Hour), validAlternativeName)
+			cache.Set(certificateURL, chain.certs)
+
+			Convey(`When I get the certificate chain`, func() {
+				certs, found := cache.Get(certificateURL)
+
+				Convey(`Then the chain will be found`, func() {
+					So(found, ShouldBeTrue)
+					So(certs, ShouldResemble, chain.certs)
+				})
+			})
+
+			Convey(`When I get the certificate chain using an equivalent URL`, func() {
+				_, found := cache.Get(`HTTPS://S3.amazonaws.com:443/echo.api/../echo.api/echo-api-cert.pem`)
+
+				Convey(`Then the chain will be found`, func() {
+					So(found, ShouldBeTrue)
+				})
+			})
+
+			Convey(`When I get the certificate chain using a URL with a differently cased path`, func() {
+				_, found := cache.Get(`https://s3.amazonaws.com/ECHO.API/echo-api-cert.pem`)
+
+				Convey(`Then the chain will not be found`, func() {
+					So(found, ShouldBeFalse)
+				})
+			})
+
+			Convey(`When I set more chains than the cache can hold`, func() {
+				cache.Set(`https://s3.amazonaws.com/echo.api/second.pem`, chain.certs)
+				cache.Set(`https://s3.amazonaws.com/echo.api/third.pem`, chain.certs)
+
+				Convey(`Then the cache will be bounded`, func() {
+					So(cache.Len(), ShouldEqual, 2)
+				})
+
+				Convey(`Then the least recently used chain will have been evicted`, func() {
+					_, found := cache.Get(certificateURL)
+					So(found, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey(`And I set an expired certificate chain`, func() {
+			chain := newTestCertificateChain(now.Add(-2*time.Hour), now.Add(-1*time.Hour), validAlternativeName)
+			cache.Set(certificateURL, chain.certs)
+
+			Convey(`When I get the certificate chain`, func() {
+				_, found := cache.Get(certificateURL)
+
+				Convey(`Then the chain will not be found`, func() {
+					So(found, ShouldBeFalse)
+				})
+
+				Convey(`Then the chain will have been removed from the cache`, func() {
+					So(cache.Len(), ShouldEqual, 0)
+				})
+			})
+		})
+
+		Convey(`And I set a certificate chain with a valid signing certificate and an expired intermediate certificate`, func() {
+			cache.Set(certificateURL, []*x509.Certificate{
+				{NotAfter: now.Add(time.Hour)},
+				{NotAfter: now.Add(-1 * time.Hour)},
+			})
+
+			Convey(`When I get the certificate chain`, func() {
+				_, found := cache.Get(certificateURL)
+
+				Convey(`Then the chain will not be found`, func() {
+					So(found, ShouldBeFalse)
+				})
+
+				Convey(`Then the chain will have been removed from the cache`, func() {
+					So(cache.Len(), ShouldEqual, 0)
+				})
+			})
+		})
+	})
+}
+
+func TestCertificateLoader(t *testing.T) {
+	Convey(`Given I have a certificateLoader`, t, func() {
+		loader := &certificateLoader{}
+
+		Convey(`When I load the same key concurrently`, func() {
+			var calls int32
+			release := make(chan struct{})
+			expected := []*x509.Certificate{{}}
+
+			var wait sync.WaitGroup
+			results := make([][]*x509.Certificate, 10)
+			for i := range results {
+				wait.Add(1)
+				go func(i int) {
+					defer wait.Done()
+					results[i], _ = loader.load(`key`, func() ([]*x509.Certificate, error) {
+						atomic.AddInt32(&calls, 1)
+						<-release
+						return expected, nil
+					})
+				}(i)
+			}
+			// Wait until every other goroutine is queued on the in-flight call before it completes
+			for testCertificateLoaderWaiters(loader, `key`) != len(results)-1 {
+				runtime.Gosched()
+			}
+			close(release)
+			wait.Wait()
+
+			Convey(`Then the load function will be called once`, func() {
+				So(atomic.LoadInt32(&calls), ShouldEqual, 1)
+			})
+
+			Convey(`Then every caller will receive the result`, func() {
+				for _, result := range results {
+					So(result, ShouldResemble, expected)
+				}
+			})
+		})
+	})
+}
+
+// testCertificateLoaderWaiters returns the number of callers waiting on the in-flight call for key, or -1 if there is
+// no call in flight
+func testCertificateLoaderWaiters(loader *certificateLoader, key string) int {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+	if call, found := loader.calls[key]; found {
+		return call.waiters
+	}
+	return -1
+}
+
+func TestVerifier_CertificateCache(t *testing.T) {
+	Convey(`Given I have a trusted signing certificate chain served over HTTP`, t, func() {
+		chain := newTestCertificateChain(time.Now().Add(-1*time.Hour), time.Now().Add(time.Hour), validAlternativeName)
+
+		var downloads int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&downloads, 1)
+			w.Write(chain.pem)
+		}))
+		defer server.Close()
+
+		verifier := NewVerifier(
+			WithRootCertificates(chain.roots),
+			WithCertificateCache(NewMemoryCertificateCache(DefaultCertificateCacheSize)),
+		)
+
+		body := []byte(`{"version":"1.0"}`)
+		signature := testSign(chain.key, crypto.SHA1, body)
+
+		Convey(`When I call the Verifier ValidateCertificate twice`, func() {
+			verifier.ValidateCertificate(server.URL, signature, crypto.SHA1, body)
+			valid, err := verifier.ValidateCertificate(server.URL, signature, crypto.SHA1, body)
+
+			Convey(`Then the second call will be valid`, func() {
+				So(err, ShouldBeNil)
+				So(valid, ShouldBeTrue)
+			})
+
+			Convey(`Then the certificate chain will only be downloaded once`, func() {
+				So(atomic.LoadInt32(&downloads), ShouldEqual, 1)
+			})
+		})
+	})
+
+	Convey(`Given I have two Verifiers with the default certificate cache and different root certificates`, t, func() {
+		chain := newTestCertificateChain(time.Now().Add(-1*time.Hour), time.Now().Add(time.Hour), validAlternativeName)
+		otherChain := newTestCertificateChain(time.Now().Add(-1*time.Hour), time.Now().Add(time.Hour), validAlternativeName)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write(chain.pem)
+		}))
+		defer server.Close()
+
+		trustingVerifier := NewVerifier(WithRootCertificates(chain.roots))
+		otherVerifier := NewVerifier(WithRootCertificates(otherChain.roots))
+
+		body := []byte(`{"version":"1.0"}`)
+		signature := testSign(chain.key, crypto.SHA256, body)
+
+		Convey(`When the chain has been verified by the Verifier which trusts it`, func() {
+			trustingVerifier.ValidateCertificate(server.URL, signature, crypto.SHA256, body)
+
+			Convey(`And I call ValidateCertificate on the other Verifier`, func() {
+				valid, err := otherVerifier.ValidateCertificate(server.URL, signature, crypto.SHA256, body)
+
+				Convey(`Then the chain will not be trusted`, func() {
+					So(err, ShouldNotBeNil)
+					So(valid, ShouldBeFalse)
+				})
+			})
+		})
+	})
+
+	Convey(`Given I have a Verifier with a clock and the default certificate cache`, t, func() {
+		now := time.Now()
+		chain := newTestCertificateChain(now.Add(-1*time.Hour), now.Add(time.Hour), validAlternativeName)
+
+		var downloads int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			atomic.AddInt32(&downloads, 1)
+			w.Write(chain.pem)
+		}))
+		defer server.Close()
+
+		clock := now
+		verifier := NewVerifier(WithRootCertificates(chain.roots), WithClock(func() time.Time { return clock }))
+
+		body := []byte(`{"version":"1.0"}`)
+		signature := testSign(chain.key, crypto.SHA256, body)
+
+		Convey(`When I call ValidateCertificate after the clock has passed the expiry of the cached chain`, func() {
+			verifier.ValidateCertificate(server.URL, signature, crypto.SHA256, body)
+			clock = now.Add(2 * time.Hour)
+			valid, err := verifier.ValidateCertificate(server.URL, signature, crypto.SHA256, body)
+
+			Convey(`Then the chain will not be valid`, func() {
+				So(err, ShouldNotBeNil)
+				So(valid, ShouldBeFalse)
+			})
+
+			Convey(`Then the certificate chain will have been downloaded again`, func() {
+				So(atomic.LoadInt32(&downloads), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey(`Given I have a Verifier with a clock and a certificate cache which uses another clock`, t, func() {
+		now := time.Now()
+		chain := newTestCertificateChain(now.Add(-1*time.Hour), now.Add(time.Hour), validAlternativeName)
+		certificateURL := `https://s3.amazonaws.com/echo.api/echo-api-cert.pem`
+
+		cache := NewMemoryCertificateCache(DefaultCertificateCacheSize)
+		cache.Set(certificateURL, chain.certs)
+		verifier := NewVerifier(
+			WithCertificateCache(cache),
+			WithClock(func() time.Time { return now.Add(2 * time.Hour) }),
+			WithHTTPClient(&http.Client{Transport: &testCertificateTransport{pem: chain.pem}}),
+		)
+
+		body := []byte(`{"version":"1.0"}`)
+
+		Convey(`When I call ValidateCertificate after the Verifier clock has passed the expiry of the cached chain`, func() {
+			valid, err := verifier.ValidateCertificate(certificateURL, testSign(chain.key, crypto.SHA256, body), crypto.SHA256, body)
+
+			Convey(`Then the cached chain will not be used`, func() {
+				So(err, ShouldNotBeNil)
+				So(valid, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey(`Given I have pre-seeded the certificate cache`, t, func() {
+		chain := newTestCertificateChain(time.Now().Add(-1*time.Hour), time.Now().Add(time.Hour), validAlternativeName)
+		certificateURL := `https://s3.amazonaws.com/echo.api/echo-api-cert.pem`
+
+		cache := NewMemoryCertificateCache(DefaultCertificateCacheSize)
+		cache.Set(certificateURL, chain.certs)
+		verifier := NewVerifier(WithCertificateCache(cache))
+
+		body := []byte(`{"version":"1.0"}`)
+
+		Convey(`When I call the Verifier ValidateCertificate with the cached URL`, func() {
+			valid, err := verifier.ValidateCertificate(certificateURL, testSign(chain.key, crypto.SHA256, body), crypto.SHA256, body)
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the result will be true`, func() {
+				So(valid, ShouldBeTrue)
+			})
+		})
+	})
+}
@@ -0,0 +1,51 @@
+package alexa
+
+import (
+	"context"
+)
+
+// contextKey is the type of the keys used to store values on a context.Context by this package
+type contextKey int
+
+const (
+	// applicationIDContextKey is the key for the application ID a request was verified against
+	applicationIDContextKey contextKey = iota
+	// requestContextKey is the key for the decoded Alexa request
+	requestContextKey
+	// requestBodyContextKey is the key for the raw body of the Alexa request
+	requestBodyContextKey
+)
+
+// NewContext returns a copy of ctx holding the decoded Alexa request and the raw request body it was decoded from. The
+// Verifier Middleware stores every verified request on the context in this way, so that handlers do not need to decode
+// the body a second time.
+func NewContext(ctx context.Context, request *Request, body []byte) context.Context {
+	ctx = context.WithValue(ctx, requestContextKey, request)
+	return context.WithValue(ctx, requestBodyContextKey, body)
+}
+
+// RequestFromContext returns the decoded Alexa request stored on ctx by NewContext. The boolean is false if no request
+// is present on ctx.
+func RequestFromContext(ctx context.Context) (*Request, bool) {
+	request, ok := ctx.Value(requestContextKey).(*Request)
+	return request, ok && request != nil
+}
+
+// RequestBodyFromContext returns the raw body of the Alexa request stored on ctx by NewContext. The boolean is false if
+// no body is present on ctx.
+func RequestBodyFromContext(ctx context.Context) ([]byte, bool) {
+	body, ok := ctx.Value(requestBodyContextKey).([]byte)
+	return body, ok
+}
+
+// ApplicationIDFromContext returns the application ID that a request was verified against by a Verifier configured
+// with WithApplicationIDs. The boolean is false if no application ID is present on ctx.
+func ApplicationIDFromContext(ctx context.Context) (string, bool) {
+	applicationID, ok := ctx.Value(applicationIDContextKey).(string)
+	return applicationID, ok
+}
+
+// withApplicationID returns a copy of ctx holding applicationID
+func withApplicationID(ctx context.Context, applicationID string) context.Context {
+	return context.WithValue(ctx, applicationIDContextKey, applicationID)
+}
@@ -0,0 +1,63 @@
+package alexa
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNewContext(t *testing.T) {
+	Convey(`Given I have a context holding a request and its body`, t, func() {
+		request := &Request{Version: `1.0`}
+		body := []byte(`{"version":"1.0"}`)
+		ctx := NewContext(context.Background(), request, body)
+
+		Convey(`When I call RequestFromContext`, func() {
+			result, found := RequestFromContext(ctx)
+
+			Convey(`Then the request will be found`, func() {
+				So(found, ShouldBeTrue)
+				So(result, ShouldEqual, request)
+			})
+		})
+
+		Convey(`When I call RequestBodyFromContext`, func() {
+			result, found := RequestBodyFromContext(ctx)
+
+			Convey(`Then the body will be found`, func() {
+				So(found, ShouldBeTrue)
+				So(result, ShouldResemble, body)
+			})
+		})
+	})
+
+	Convey(`Given I have an empty context`, t, func() {
+		ctx := context.Background()
+
+		Convey(`When I call RequestFromContext`, func() {
+			result, found := RequestFromContext(ctx)
+
+			Convey(`Then the request will not be found`, func() {
+				So(found, ShouldBeFalse)
+				So(result, ShouldBeNil)
+			})
+		})
+
+		Convey(`When I call RequestBodyFromContext`, func() {
+			_, found := RequestBodyFromContext(ctx)
+
+			Convey(`Then the body will not be found`, func() {
+				So(found, ShouldBeFalse)
+			})
+		})
+
+		Convey(`When I call ApplicationIDFromContext`, func() {
+			_, found := ApplicationIDFromContext(ctx)
+
+			Convey(`Then the application ID will not be found`, func() {
+				So(found, ShouldBeFalse)
+			})
+		})
+	})
+}
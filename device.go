@@ -0,0 +1,202 @@
+package alexa
+
+// DeviceClass is a broad classification of a device, used to choose a response suited to it
+type DeviceClass string
+
+// ViewportKeyboard is a type of keyboard input supported by a Viewport
+type ViewportKeyboard string
+
+// ViewportMode indicates how a device with a screen is used
+type ViewportMode string
+
+// ViewportShape is the shape of the screen of a device
+type ViewportShape string
+
+// ViewportTouch is a type of touch input supported by a Viewport
+type ViewportTouch string
+
+const (
+	// DeviceClassVoiceOnly is a device without a screen, such as an Echo
+	DeviceClassVoiceOnly DeviceClass = "VOICE_ONLY"
+	// DeviceClassHubRound is a hub with a round screen, such as an Echo Spot
+	DeviceClassHubRound DeviceClass = "HUB_ROUND"
+	// DeviceClassHubLandscape is a hub with a landscape screen, such as an Echo Show
+	DeviceClassHubLandscape DeviceClass = "HUB_LANDSCAPE"
+	// DeviceClassHubPortrait is a hub with a portrait screen
+	DeviceClassHubPortrait DeviceClass = "HUB_PORTRAIT"
+	// DeviceClassTV is a television, such as a Fire TV
+	DeviceClassTV DeviceClass = "TV"
+	// DeviceClassMobile is a mobile device, such as a tablet
+	DeviceClassMobile DeviceClass = "MOBILE"
+	// DeviceClassPC is a desktop or laptop computer
+	DeviceClassPC DeviceClass = "PC"
+	// DeviceClassUnknown is a device with a screen that does not fit another class
+	DeviceClassUnknown DeviceClass = "UNKNOWN"
+
+	// ViewportKeyboardDirection indicates the device supports directional keys, such as a remote control
+	ViewportKeyboardDirection ViewportKeyboard = "DIRECTION"
+
+	// ViewportModeHub is a device used at arm's length or further, such as an Echo Show
+	ViewportModeHub ViewportMode = "HUB"
+	// ViewportModeTV is a device used at a distance, such as a television
+	ViewportModeTV ViewportMode = "TV"
+	// ViewportModePC is a desktop or laptop computer
+	ViewportModePC ViewportMode = "PC"
+	// ViewportModeMobile is a handheld device
+	ViewportModeMobile ViewportMode = "MOBILE"
+	// ViewportModeAuto is a device used in a vehicle
+	ViewportModeAuto ViewportMode = "AUTO"
+
+	// ViewportShapeRectangle is a rectangular screen
+	ViewportShapeRectangle ViewportShape = "RECTANGLE"
+	// ViewportShapeRound is a round screen
+	ViewportShapeRound ViewportShape = "ROUND"
+
+	// ViewportTouchSingle indicates the device supports single point touch input
+	ViewportTouchSingle ViewportTouch = "SINGLE"
+)
+
+// Supports reports whether the device supports the interface
+func (device *Device) Supports(supportedInterface SupportedInterfaces) bool {
+	if device == nil {
+		return false
+	}
+	_, hasSupport := device.SupportedInterfaces[supportedInterface]
+	return hasSupport
+}
+
+// HasAudioPlayerSupport reports whether the device supports streaming audio with AudioPlayer directives
+func (device *Device) HasAudioPlayerSupport() bool {
+	return device.Supports(AudioPlayerSupported)
+}
+
+// HasDisplaySupport reports whether the device supports Display templates
+func (device *Device) HasDisplaySupport() bool {
+	return device.Supports(DisplaySupported)
+}
+
+// HasVideoAppSupport reports whether the device supports playing video
+func (device *Device) HasVideoAppSupport() bool {
+	return device.Supports(VideoAppSupported)
+}
+
+// HasAPLSupport reports whether the device supports Alexa Presentation Language documents
+func (device *Device) HasAPLSupport() bool {
+	return device.Supports(APLSupported)
+}
+
+// HasGeolocationSupport reports whether the device can share its location
+func (device *Device) HasGeolocationSupport() bool {
+	return device.Supports(GeolocationSupported)
+}
+
+// APLMaxVersion returns the latest version of APL supported by the device, or an empty string if the device does not
+// support APL or does not report its version
+func (device *Device) APLMaxVersion() string {
+	if device == nil {
+		return ""
+	}
+	apl, _ := device.SupportedInterfaces[APLSupported].(map[string]interface{})
+	runtime, _ := apl[`runtime`].(map[string]interface{})
+	version, _ := runtime[`maxVersion`].(string)
+	return version
+}
+
+// Viewport describes the screen of a device
+type Viewport struct {
+	// Experiences lists the ways the device can be used, such as at different orientations
+	Experiences []*ViewportExperience `json:"experiences"`
+
+	// Mode indicates how the device is used
+	Mode ViewportMode `json:"mode"`
+
+	// Shape is the shape of the screen
+	Shape ViewportShape `json:"shape"`
+
+	// PixelWidth and PixelHeight are the size of the screen in pixels
+	PixelWidth  int `json:"pixelWidth"`
+	PixelHeight int `json:"pixelHeight"`
+
+	// CurrentPixelWidth and CurrentPixelHeight are the size of the screen area available to the skill in pixels
+	CurrentPixelWidth  int `json:"currentPixelWidth"`
+	CurrentPixelHeight int `json:"currentPixelHeight"`
+
+	// DPI is the pixel density of the screen
+	DPI int `json:"dpi"`
+
+	// Touch lists the touch input the device supports. It is empty if the screen is not a touch screen
+	Touch []ViewportTouch `json:"touch"`
+
+	// Keyboard lists the keyboard input the device supports
+	Keyboard []ViewportKeyboard `json:"keyboard"`
+
+	// Video describes the video the device can play. It is only included if the device supports video
+	Video *ViewportVideo `json:"video"`
+}
+
+// ViewportExperience is a way a device with a screen can be used
+type ViewportExperience struct {
+	// ArcMinuteWidth and ArcMinuteHeight are the size of the screen in the user's field of view
+	ArcMinuteWidth  int `json:"arcMinuteWidth"`
+	ArcMinuteHeight int `json:"arcMinuteHeight"`
+
+	// CanRotate indicates the screen can be rotated
+	CanRotate bool `json:"canRotate"`
+
+	// CanResize indicates the screen can be resized
+	CanResize bool `json:"canResize"`
+}
+
+// ViewportVideo describes the video a device can play
+type ViewportVideo struct {
+	// Codecs lists the video codecs supported, such as H_264_41
+	Codecs []string `json:"codecs"`
+}
+
+// IsRound reports whether the screen is round
+func (viewport *Viewport) IsRound() bool {
+	return viewport != nil && viewport.Shape == ViewportShapeRound
+}
+
+// IsLandscape reports whether the screen is wider than it is tall
+func (viewport *Viewport) IsLandscape() bool {
+	return viewport != nil && viewport.PixelWidth > viewport.PixelHeight
+}
+
+// HasTouch reports whether the screen supports touch input
+func (viewport *Viewport) HasTouch() bool {
+	return viewport != nil && len(viewport.Touch) > 0
+}
+
+// DeviceClass classifies the device which sent the request using its viewport. A device without a viewport is
+// DeviceClassVoiceOnly.
+func (context *Context) DeviceClass() DeviceClass {
+	if context == nil || context.Viewport == nil {
+		return DeviceClassVoiceOnly
+	}
+
+	viewport := context.Viewport
+	switch viewport.Mode {
+	case ViewportModeTV:
+		return DeviceClassTV
+	case ViewportModeMobile:
+		return DeviceClassMobile
+	case ViewportModePC:
+		return DeviceClassPC
+	case ViewportModeHub:
+		switch {
+		case viewport.IsRound():
+			return DeviceClassHubRound
+		case viewport.IsLandscape():
+			return DeviceClassHubLandscape
+		default:
+			return DeviceClassHubPortrait
+		}
+	}
+	return DeviceClassUnknown
+}
+
+// IsVoiceOnly reports whether the device which sent the request has no screen
+func (context *Context) IsVoiceOnly() bool {
+	return context.DeviceClass() == DeviceClassVoiceOnly
+}
@@ -0,0 +1,123 @@
+package alexa
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeviceSupports(t *testing.T) {
+	Convey(`Given the context of a request from an Echo Show`, t, func() {
+		context := &Context{}
+		if err := json.Unmarshal(echoShowContextJSON, context); err != nil {
+			panic(err)
+		}
+		device := context.System.Device
+
+		Convey(`Then the supported interfaces will be reported`, func() {
+			So(device.HasAudioPlayerSupport(), ShouldBeTrue)
+			So(device.HasDisplaySupport(), ShouldBeTrue)
+			So(device.HasAPLSupport(), ShouldBeTrue)
+			So(device.HasVideoAppSupport(), ShouldBeFalse)
+			So(device.HasGeolocationSupport(), ShouldBeFalse)
+		})
+
+		Convey(`Then the maximum APL version will be reported`, func() {
+			So(device.APLMaxVersion(), ShouldEqual, `1.8`)
+		})
+
+		Convey(`Then the viewport will be decoded`, func() {
+			So(context.Viewport.Shape, ShouldEqual, ViewportShapeRectangle)
+			So(context.Viewport.PixelWidth, ShouldEqual, 1280)
+			So(context.Viewport.DPI, ShouldEqual, 160)
+			So(context.Viewport.HasTouch(), ShouldBeTrue)
+			So(context.Viewport.Experiences, ShouldHaveLength, 1)
+		})
+
+		Convey(`Then the device will be a landscape hub`, func() {
+			So(context.DeviceClass(), ShouldEqual, DeviceClassHubLandscape)
+			So(context.IsVoiceOnly(), ShouldBeFalse)
+		})
+	})
+
+	Convey(`Given a context without a device or viewport`, t, func() {
+		context := &Context{System: &System{}}
+
+		Convey(`Then no interfaces will be supported`, func() {
+			So(context.System.Device.Supports(AudioPlayerSupported), ShouldBeFalse)
+			So(context.System.Device.APLMaxVersion(), ShouldBeEmpty)
+		})
+
+		Convey(`Then the device will be voice only`, func() {
+			So(context.DeviceClass(), ShouldEqual, DeviceClassVoiceOnly)
+			So(context.IsVoiceOnly(), ShouldBeTrue)
+		})
+	})
+}
+
+func TestContextDeviceClass(t *testing.T) {
+	viewports := map[DeviceClass]*Viewport{
+		DeviceClassHubRound:     {Mode: ViewportModeHub, Shape: ViewportShapeRound, PixelWidth: 480, PixelHeight: 480},
+		DeviceClassHubLandscape: {Mode: ViewportModeHub, Shape: ViewportShapeRectangle, PixelWidth: 1024, PixelHeight: 600},
+		DeviceClassHubPortrait:  {Mode: ViewportModeHub, Shape: ViewportShapeRectangle, PixelWidth: 600, PixelHeight: 1024},
+		DeviceClassTV:           {Mode: ViewportModeTV, Shape: ViewportShapeRectangle, PixelWidth: 1920, PixelHeight: 1080},
+		DeviceClassMobile:       {Mode: ViewportModeMobile, Shape: ViewportShapeRectangle},
+		DeviceClassPC:           {Mode: ViewportModePC, Shape: ViewportShapeRectangle},
+		DeviceClassUnknown:      {Mode: ViewportModeAuto, Shape: ViewportShapeRectangle},
+	}
+
+	for expected, viewport := range viewports {
+		Convey(`When I classify a `+string(viewport.Mode)+` device with a `+string(viewport.Shape)+` viewport`, t, func() {
+			class := (&Context{Viewport: viewport}).DeviceClass()
+
+			Convey(`Then it will be `+string(expected), func() {
+				So(class, ShouldEqual, expected)
+			})
+		})
+	}
+}
+
+var echoShowContextJSON = []byte(`
+{
+	"System": {
+		"application": {
+			"applicationId": "amzn1.ask.skill.1"
+		},
+		"device": {
+			"deviceId": "amzn1.ask.device.1",
+			"supportedInterfaces": {
+				"AudioPlayer": {},
+				"Display": {
+					"templateVersion": "1.0",
+					"markupVersion": "1.0"
+				},
+				"Alexa.Presentation.APL": {
+					"runtime": {
+						"maxVersion": "1.8"
+					}
+				}
+			}
+		}
+	},
+	"Viewport": {
+		"experiences": [
+			{
+				"arcMinuteWidth": 246,
+				"arcMinuteHeight": 144,
+				"canRotate": false,
+				"canResize": false
+			}
+		],
+		"mode": "HUB",
+		"shape": "RECTANGLE",
+		"pixelWidth": 1280,
+		"pixelHeight": 800,
+		"dpi": 160,
+		"currentPixelWidth": 1280,
+		"currentPixelHeight": 800,
+		"touch": ["SINGLE"],
+		"keyboard": []
+	}
+}
+`)
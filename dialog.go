@@ -0,0 +1,256 @@
+package alexa
+
+import (
+	"encoding/json"
+)
+
+const (
+	// DialogDelegateDirectiveType sends Alexa a command to handle the next turn in the dialog with the user
+	DialogDelegateDirectiveType DirectiveType = `Dialog.Delegate`
+	// DialogElicitSlotDirectiveType sends Alexa a command to ask the user for the value of a specific slot
+	DialogElicitSlotDirectiveType DirectiveType = `Dialog.ElicitSlot`
+	// DialogConfirmSlotDirectiveType sends Alexa a command to confirm the value of a specific slot before continuing
+	// with the dialog
+	DialogConfirmSlotDirectiveType DirectiveType = `Dialog.ConfirmSlot`
+	// DialogConfirmIntentDirectiveType sends Alexa a command to confirm all the information the user has provided for
+	// the intent before the skill takes action
+	DialogConfirmIntentDirectiveType DirectiveType = `Dialog.ConfirmIntent`
+)
+
+// DialogDelegateDirective sends Alexa a command to handle the next turn in the dialog with the user, using the prompts
+// defined in the dialog model. The response cannot include output speech or a reprompt.
+//
+// Dialog directives can only be returned in response to an IntentRequest which includes a DialogState, and
+// ShouldEndSession must be false.
+type DialogDelegateDirective struct {
+	// UpdatedIntent is an intent which can change the values for slots and the confirmation status of slots and the
+	// intent. It is optional, if omitted the intent from the request is used
+	UpdatedIntent *Intent `json:"updatedIntent,omitempty"`
+}
+
+// GetType returns the DialogDelegateDirectiveType
+func (directive *DialogDelegateDirective) GetType() DirectiveType {
+	return DialogDelegateDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the DialogDelegateDirective type, setting the type field
+func (directive *DialogDelegateDirective) MarshalJSON() ([]byte, error) {
+	type Alias DialogDelegateDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// DialogElicitSlotDirective sends Alexa a command to ask the user for the value of a specific slot. The response must
+// include output speech asking the user for the slot value.
+type DialogElicitSlotDirective struct {
+	// SlotToElicit is the name of the slot to ask the user about
+	SlotToElicit string `json:"slotToElicit"`
+
+	// UpdatedIntent is an intent which can change the values for slots and the confirmation status of slots and the
+	// intent. It is optional, if omitted the intent from the request is used
+	UpdatedIntent *Intent `json:"updatedIntent,omitempty"`
+}
+
+// GetType returns the DialogElicitSlotDirectiveType
+func (directive *DialogElicitSlotDirective) GetType() DirectiveType {
+	return DialogElicitSlotDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the DialogElicitSlotDirective type, setting the type field
+func (directive *DialogElicitSlotDirective) MarshalJSON() ([]byte, error) {
+	type Alias DialogElicitSlotDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// DialogConfirmSlotDirective sends Alexa a command to confirm the value of a specific slot before continuing with the
+// dialog. The response must include output speech asking the user to confirm the slot value.
+type DialogConfirmSlotDirective struct {
+	// SlotToConfirm is the name of the slot to confirm
+	SlotToConfirm string `json:"slotToConfirm"`
+
+	// UpdatedIntent is an intent which can change the values for slots and the confirmation status of slots and the
+	// intent. It is optional, if omitted the intent from the request is used
+	UpdatedIntent *Intent `json:"updatedIntent,omitempty"`
+}
+
+// GetType returns the DialogConfirmSlotDirectiveType
+func (directive *DialogConfirmSlotDirective) GetType() DirectiveType {
+	return DialogConfirmSlotDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the DialogConfirmSlotDirective type, setting the type field
+func (directive *DialogConfirmSlotDirective) MarshalJSON() ([]byte, error) {
+	type Alias DialogConfirmSlotDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// DialogConfirmIntentDirective sends Alexa a command to confirm all the information the user has provided for the
+// intent before the skill takes action. The response must include output speech asking the user to confirm the intent.
+type DialogConfirmIntentDirective struct {
+	// UpdatedIntent is an intent which can change the values for slots and the confirmation status of slots and the
+	// intent. It is optional, if omitted the intent from the request is used
+	UpdatedIntent *Intent `json:"updatedIntent,omitempty"`
+}
+
+// GetType returns the DialogConfirmIntentDirectiveType
+func (directive *DialogConfirmIntentDirective) GetType() DirectiveType {
+	return DialogConfirmIntentDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the DialogConfirmIntentDirective type, setting the type
+// field
+func (directive *DialogConfirmIntentDirective) MarshalJSON() ([]byte, error) {
+	type Alias DialogConfirmIntentDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// NewDelegateResponse returns a Response delegating the next turn of the dialog to Alexa. updatedIntent may be nil.
+func NewDelegateResponse(updatedIntent *Intent) *Response {
+	return &Response{
+		Response: &ResponseData{
+			Directives:       []Directive{&DialogDelegateDirective{UpdatedIntent: updatedIntent}},
+			ShouldEndSession: Bool(false),
+		},
+	}
+}
+
+// NewElicitSlotResponse returns a Response asking the user for the value of the slot called slot using prompt, and
+// reprompt if the user does not respond. updatedIntent and reprompt may be nil.
+func NewElicitSlotResponse(updatedIntent *Intent, slot string, prompt, reprompt OutputSpeech) *Response {
+	return newDialogResponse(&DialogElicitSlotDirective{SlotToElicit: slot, UpdatedIntent: updatedIntent}, prompt, reprompt)
+}
+
+// NewConfirmSlotResponse returns a Response asking the user to confirm the value of the slot called slot using prompt,
+// and reprompt if the user does not respond. updatedIntent and reprompt may be nil.
+func NewConfirmSlotResponse(updatedIntent *Intent, slot string, prompt, reprompt OutputSpeech) *Response {
+	return newDialogResponse(&DialogConfirmSlotDirective{SlotToConfirm: slot, UpdatedIntent: updatedIntent}, prompt, reprompt)
+}
+
+// NewConfirmIntentResponse returns a Response asking the user to confirm the intent using prompt, and reprompt if the
+// user does not respond. updatedIntent and reprompt may be nil.
+func NewConfirmIntentResponse(updatedIntent *Intent, prompt, reprompt OutputSpeech) *Response {
+	return newDialogResponse(&DialogConfirmIntentDirective{UpdatedIntent: updatedIntent}, prompt, reprompt)
+}
+
+// newDialogResponse returns a Response keeping the session open with directive and the prompt and reprompt
+func newDialogResponse(directive Directive, prompt, reprompt OutputSpeech) *Response {
+	return &Response{
+		Response: &ResponseData{
+			OutputSpeech:     prompt,
+			Reprompt:         reprompt,
+			Directives:       []Directive{directive},
+			ShouldEndSession: Bool(false),
+		},
+	}
+}
+
+// UpdateBehavior indicates how a Dialog.UpdateDynamicEntities directive changes the dynamic entities of a skill
+type UpdateBehavior string
+
+const (
+	// DialogUpdateDynamicEntitiesDirectiveType sends Alexa a command to add or clear the dynamic entities used to
+	// resolve slot values for the remainder of the session
+	DialogUpdateDynamicEntitiesDirectiveType DirectiveType = `Dialog.UpdateDynamicEntities`
+
+	// UpdateBehaviorReplace replaces any existing dynamic entities with the types in the directive
+	UpdateBehaviorReplace UpdateBehavior = `REPLACE`
+	// UpdateBehaviorClear clears all dynamic entities. The directive must not include any types
+	UpdateBehaviorClear UpdateBehavior = `CLEAR`
+)
+
+// DialogUpdateDynamicEntitiesDirective sends Alexa a command to add or clear dynamic entities, which are used in
+// addition to the slot values defined in the interaction model when resolving slots. Dynamic entities remain in use
+// for 30 minutes or until the session ends, whichever is sooner.
+//
+// Matches against dynamic entities are reported in the authority returned by the slot's Resolution Dynamic method.
+type DialogUpdateDynamicEntitiesDirective struct {
+	// UpdateBehavior indicates whether the types replace the existing dynamic entities or all are cleared
+	UpdateBehavior UpdateBehavior `json:"updateBehavior"`
+
+	// Types lists the slot types to add dynamic entities to. Must be empty when UpdateBehavior is UpdateBehaviorClear
+	Types []*DynamicSlotType `json:"types,omitempty"`
+}
+
+// DynamicSlotType is a slot type defined in the interaction model and the dynamic entities to add to it
+type DynamicSlotType struct {
+	// Name is the name of the slot type, as defined in the interaction model
+	Name string `json:"name"`
+
+	// Values are the entities to add to the slot type
+	Values []*DynamicSlotValue `json:"values"`
+}
+
+// DynamicSlotValue is a single entity of a DynamicSlotType
+type DynamicSlotValue struct {
+	// ID is an optional identifier for the entity, returned in the resolved value when it is matched
+	ID string `json:"id,omitempty"`
+
+	// Name is the canonical value of the entity and its synonyms
+	Name *DynamicSlotValueName `json:"name"`
+}
+
+// DynamicSlotValueName is the canonical value of a DynamicSlotValue and the synonyms which also resolve to it
+type DynamicSlotValueName struct {
+	// Value is the canonical value of the entity
+	Value string `json:"value"`
+
+	// Synonyms are alternative words or phrases which resolve to the entity
+	Synonyms []string `json:"synonyms,omitempty"`
+}
+
+// NewReplaceDynamicEntitiesDirective returns a Dialog.UpdateDynamicEntities directive replacing any existing dynamic
+// entities with types
+func NewReplaceDynamicEntitiesDirective(types ...*DynamicSlotType) *DialogUpdateDynamicEntitiesDirective {
+	return &DialogUpdateDynamicEntitiesDirective{
+		UpdateBehavior: UpdateBehaviorReplace,
+		Types:          types,
+	}
+}
+
+// NewClearDynamicEntitiesDirective returns a Dialog.UpdateDynamicEntities directive clearing all dynamic entities
+func NewClearDynamicEntitiesDirective() *DialogUpdateDynamicEntitiesDirective {
+	return &DialogUpdateDynamicEntitiesDirective{
+		UpdateBehavior: UpdateBehaviorClear,
+	}
+}
+
+// GetType returns the DialogUpdateDynamicEntitiesDirectiveType
+func (directive *DialogUpdateDynamicEntitiesDirective) GetType() DirectiveType {
+	return DialogUpdateDynamicEntitiesDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the DialogUpdateDynamicEntitiesDirective type, setting the
+// type field
+func (directive *DialogUpdateDynamicEntitiesDirective) MarshalJSON() ([]byte, error) {
+	type Alias DialogUpdateDynamicEntitiesDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
@@ -0,0 +1,179 @@
+package alexa
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDialogDirectiveMarshal(t *testing.T) {
+	updatedIntent := &Intent{
+		Name:               `BookTable`,
+		ConfirmationStatus: ConfirmationStatusStateNone,
+		Slots: map[string]*Slot{
+			`time`: {Name: `time`, Value: `19:00`, ConfirmationStatus: ConfirmationStatusStateConfirmed},
+		},
+	}
+
+	Convey(`Given a Dialog.Delegate directive without an updated intent`, t, func() {
+		directive := &DialogDelegateDirective{}
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+
+			Convey(`Then there will be no error`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then only the type will be set`, func() {
+				So(string(data), ShouldEqual, `{"type":"Dialog.Delegate"}`)
+			})
+		})
+	})
+
+	Convey(`Given a Dialog.ElicitSlot directive with an updated intent`, t, func() {
+		directive := &DialogElicitSlotDirective{SlotToElicit: `partySize`, UpdatedIntent: updatedIntent}
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+			So(err, ShouldBeNil)
+
+			Convey(`Then the type, slot and updated intent will be set`, func() {
+				So(string(data), ShouldEqual, `{"type":"Dialog.ElicitSlot","slotToElicit":"partySize",`+
+					`"updatedIntent":{"name":"BookTable","confirmationStatus":"NONE",`+
+					`"slots":{"time":{"name":"time","value":"19:00","confirmationStatus":"CONFIRMED"}}}}`)
+			})
+		})
+	})
+
+	Convey(`Given a Dialog.ConfirmSlot directive`, t, func() {
+		directive := &DialogConfirmSlotDirective{SlotToConfirm: `time`}
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+			So(err, ShouldBeNil)
+
+			Convey(`Then the type and slot will be set`, func() {
+				So(string(data), ShouldEqual, `{"type":"Dialog.ConfirmSlot","slotToConfirm":"time"}`)
+			})
+		})
+	})
+
+	Convey(`Given a Dialog.ConfirmIntent directive`, t, func() {
+		directive := &DialogConfirmIntentDirective{UpdatedIntent: &Intent{Name: `BookTable`}}
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+			So(err, ShouldBeNil)
+
+			Convey(`Then the type and updated intent will be set`, func() {
+				So(string(data), ShouldEqual, `{"type":"Dialog.ConfirmIntent","updatedIntent":{"name":"BookTable"}}`)
+			})
+		})
+	})
+}
+
+func TestDialogResponses(t *testing.T) {
+	prompt := PlainSpeech(`How many people?`)
+	reprompt := PlainSpeech(`For how many people should I book?`)
+
+	Convey(`When I create a delegate response`, t, func() {
+		response := NewDelegateResponse(nil)
+
+		Convey(`Then there will be no output speech or reprompt`, func() {
+			So(response.Response.OutputSpeech, ShouldBeNil)
+			So(response.Response.Reprompt, ShouldBeNil)
+		})
+
+		Convey(`Then the session will be kept open`, func() {
+			So(response.Response.ShouldEndSession, ShouldResemble, Bool(false))
+		})
+
+		Convey(`Then the directive will be a Dialog.Delegate`, func() {
+			So(response.Response.Directives, ShouldHaveLength, 1)
+			So(response.Response.Directives[0].GetType(), ShouldEqual, DialogDelegateDirectiveType)
+		})
+	})
+
+	Convey(`When I create an elicit slot response`, t, func() {
+		response := NewElicitSlotResponse(nil, `partySize`, prompt, reprompt)
+
+		Convey(`Then the prompt and reprompt will be set`, func() {
+			So(response.Response.OutputSpeech, ShouldEqual, prompt)
+			So(response.Response.Reprompt, ShouldEqual, reprompt)
+		})
+
+		Convey(`Then the directive will elicit the slot`, func() {
+			So(response.Response.Directives, ShouldHaveLength, 1)
+			directive, ok := response.Response.Directives[0].(*DialogElicitSlotDirective)
+			So(ok, ShouldBeTrue)
+			So(directive.SlotToElicit, ShouldEqual, `partySize`)
+		})
+	})
+
+	Convey(`When I create a confirm slot response`, t, func() {
+		response := NewConfirmSlotResponse(nil, `time`, prompt, nil)
+
+		Convey(`Then the directive will confirm the slot`, func() {
+			directive, ok := response.Response.Directives[0].(*DialogConfirmSlotDirective)
+			So(ok, ShouldBeTrue)
+			So(directive.SlotToConfirm, ShouldEqual, `time`)
+		})
+
+		Convey(`Then the reprompt will be nil`, func() {
+			So(response.Response.Reprompt, ShouldBeNil)
+		})
+	})
+
+	Convey(`When I create a confirm intent response with an updated intent`, t, func() {
+		intent := &Intent{Name: `BookTable`}
+		response := NewConfirmIntentResponse(intent, prompt, reprompt)
+
+		Convey(`Then the directive will carry the updated intent`, func() {
+			directive, ok := response.Response.Directives[0].(*DialogConfirmIntentDirective)
+			So(ok, ShouldBeTrue)
+			So(directive.UpdatedIntent, ShouldEqual, intent)
+		})
+	})
+}
+
+func TestDialogUpdateDynamicEntitiesDirectiveMarshal(t *testing.T) {
+	Convey(`Given a directive replacing the dynamic entities`, t, func() {
+		directive := NewReplaceDynamicEntitiesDirective(&DynamicSlotType{
+			Name: `Dish`,
+			Values: []*DynamicSlotValue{
+				{ID: `dish-1`, Name: &DynamicSlotValueName{Value: `pad thai`, Synonyms: []string{`noodles`}}},
+				{Name: &DynamicSlotValueName{Value: `green curry`}},
+			},
+		})
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+
+			Convey(`Then there will be no error`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the behaviour, types, values and synonyms will be set`, func() {
+				So(string(data), ShouldEqual, `{"type":"Dialog.UpdateDynamicEntities","updateBehavior":"REPLACE",`+
+					`"types":[{"name":"Dish","values":[`+
+					`{"id":"dish-1","name":{"value":"pad thai","synonyms":["noodles"]}},`+
+					`{"name":{"value":"green curry"}}]}]}`)
+			})
+		})
+	})
+
+	Convey(`Given a directive clearing the dynamic entities`, t, func() {
+		directive := NewClearDynamicEntitiesDirective()
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+			So(err, ShouldBeNil)
+
+			Convey(`Then the types will be omitted`, func() {
+				So(string(data), ShouldEqual, `{"type":"Dialog.UpdateDynamicEntities","updateBehavior":"CLEAR"}`)
+			})
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package alexa
+
+// DirectiveType indicates the type of a directive included in a Response
+type DirectiveType string
+
+// ImageSize indicates the size of an ImageSource
+type ImageSize string
+
+const (
+	// ImageSizeXSmall is an image 480 x 320 pixels
+	ImageSizeXSmall ImageSize = "X_SMALL"
+	// ImageSizeSmall is an image 720 x 480 pixels
+	ImageSizeSmall ImageSize = "SMALL"
+	// ImageSizeMedium is an image 960 x 640 pixels
+	ImageSizeMedium ImageSize = "MEDIUM"
+	// ImageSizeLarge is an image 1200 x 800 pixels
+	ImageSizeLarge ImageSize = "LARGE"
+	// ImageSizeXLarge is an image 1920 x 1280 pixels
+	ImageSizeXLarge ImageSize = "X_LARGE"
+)
+
+// Directive is an interface for the directives that can be included in the Directives field of ResponseData. A
+// directive instructs the device to take an action, such as streaming audio or rendering a template.
+//
+// Each implementation marshals itself to JSON including its type field.
+type Directive interface {
+	GetType() DirectiveType
+	MarshalJSON() ([]byte, error)
+}
+
+// Image is an image that can be displayed by a device, such as the art for an audio stream. An image is provided as a
+// set of sources so that the device can select the most appropriate size.
+type Image struct {
+	// ContentDescription is a text description of the image, used for accessibility
+	ContentDescription string `json:"contentDescription,omitempty"`
+
+	// Sources is a list of the available sizes of the image
+	Sources []*ImageSource `json:"sources"`
+}
+
+// ImageSource is one size of an Image
+type ImageSource struct {
+	// URL is the HTTPS location of the image
+	URL string `json:"url"`
+
+	// Size is the named size of the image. It is optional
+	Size ImageSize `json:"size,omitempty"`
+
+	// WidthPixels is the width of the image in pixels. It is optional
+	WidthPixels int `json:"widthPixels,omitempty"`
+
+	// HeightPixels is the height of the image in pixels. It is optional
+	HeightPixels int `json:"heightPixels,omitempty"`
+}
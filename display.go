@@ -0,0 +1,183 @@
+package alexa
+
+import (
+	"encoding/json"
+)
+
+// BackButtonBehavior indicates whether a device should show a back button on a Display template
+type BackButtonBehavior string
+
+// DisplayTemplateType indicates the layout of a Display template
+type DisplayTemplateType string
+
+// TextContentType indicates how the text of a TextField is rendered
+type TextContentType string
+
+const (
+	// DisplayRenderTemplateDirectiveType sends a template to be rendered on a device with a screen
+	DisplayRenderTemplateDirectiveType DirectiveType = `Display.RenderTemplate`
+	// HintDirectiveType sends a hint suggesting an utterance to the user, shown on a device with a screen
+	HintDirectiveType DirectiveType = `Hint`
+
+	// DisplayElementSelectedRequestType is sent when the user selects an element of a Display template by touch
+	DisplayElementSelectedRequestType RequestTypeName = `Display.ElementSelected`
+
+	// BackButtonVisible shows the back button
+	BackButtonVisible BackButtonBehavior = `VISIBLE`
+	// BackButtonHidden hides the back button
+	BackButtonHidden BackButtonBehavior = `HIDDEN`
+
+	// BodyTemplate1Type is a template of text with an optional background image
+	BodyTemplate1Type DisplayTemplateType = `BodyTemplate1`
+	// BodyTemplate2Type is a template of an image on the right with text on the left
+	BodyTemplate2Type DisplayTemplateType = `BodyTemplate2`
+	// BodyTemplate3Type is a template of an image on the left with text on the right
+	BodyTemplate3Type DisplayTemplateType = `BodyTemplate3`
+	// BodyTemplate6Type is a template of text over a full screen background image
+	BodyTemplate6Type DisplayTemplateType = `BodyTemplate6`
+	// BodyTemplate7Type is a template of a single foreground image over a background image
+	BodyTemplate7Type DisplayTemplateType = `BodyTemplate7`
+	// ListTemplate1Type is a template of a vertical list of items
+	ListTemplate1Type DisplayTemplateType = `ListTemplate1`
+	// ListTemplate2Type is a template of a horizontal list of image items
+	ListTemplate2Type DisplayTemplateType = `ListTemplate2`
+
+	// TextContentTypePlain renders the text as it is given
+	TextContentTypePlain TextContentType = `PlainText`
+	// TextContentTypeRich renders the text as markup, supporting tags such as <b>, <i>, <br/> and <font size="n">
+	TextContentTypeRich TextContentType = `RichText`
+)
+
+// TextField is a piece of text displayed in a template or hint
+type TextField struct {
+	// Type indicates whether the text is plain or rich text
+	Type TextContentType `json:"type"`
+
+	// Text is the text to display
+	Text string `json:"text"`
+}
+
+// NewPlainText returns a TextField displaying text as it is given
+func NewPlainText(text string) *TextField {
+	return &TextField{Type: TextContentTypePlain, Text: text}
+}
+
+// NewRichText returns a TextField displaying text as rich text markup
+func NewRichText(text string) *TextField {
+	return &TextField{Type: TextContentTypeRich, Text: text}
+}
+
+// TextContent is the text of a template or list item. The appearance of each field depends on the template.
+type TextContent struct {
+	PrimaryText   *TextField `json:"primaryText,omitempty"`
+	SecondaryText *TextField `json:"secondaryText,omitempty"`
+	TertiaryText  *TextField `json:"tertiaryText,omitempty"`
+}
+
+// ListItem is an item in a ListTemplate1Type or ListTemplate2Type template
+type ListItem struct {
+	// Token identifies the item, and is sent in a DisplayElementSelectedRequest when the item is selected
+	Token string `json:"token"`
+
+	// Image is displayed with the item. It is optional
+	Image *Image `json:"image,omitempty"`
+
+	// TextContent is displayed with the item. It is optional
+	TextContent *TextContent `json:"textContent,omitempty"`
+}
+
+// DisplayTemplate describes the content rendered by a DisplayRenderTemplateDirective. The fields which are used depend
+// on Type; list templates use ListItems in place of Image and TextContent.
+type DisplayTemplate struct {
+	// Type is the layout of the template
+	Type DisplayTemplateType `json:"type"`
+
+	// Token identifies the template, and is sent in a DisplayElementSelectedRequest when the template is selected
+	Token string `json:"token"`
+
+	// BackButton indicates whether the back button is shown. The device default is used if it is empty
+	BackButton BackButtonBehavior `json:"backButton,omitempty"`
+
+	// BackgroundImage is displayed behind the content of the template. It is optional
+	BackgroundImage *Image `json:"backgroundImage,omitempty"`
+
+	// Title is displayed at the top of the template. It is optional
+	Title string `json:"title,omitempty"`
+
+	// Image is the foreground image of a body template. It is optional
+	Image *Image `json:"image,omitempty"`
+
+	// TextContent is the text of a body template. It is optional
+	TextContent *TextContent `json:"textContent,omitempty"`
+
+	// ListItems are the items of a list template
+	ListItems []*ListItem `json:"listItems,omitempty"`
+}
+
+// DisplayRenderTemplateDirective sends a template to be rendered on a device with a screen. It should only be included
+// in a response when the device supports the Display interface.
+type DisplayRenderTemplateDirective struct {
+	// Template is the template to render
+	Template *DisplayTemplate `json:"template"`
+}
+
+// GetType returns the DisplayRenderTemplateDirectiveType
+func (directive *DisplayRenderTemplateDirective) GetType() DirectiveType {
+	return DisplayRenderTemplateDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the DisplayRenderTemplateDirective type, setting the type
+// field
+func (directive *DisplayRenderTemplateDirective) MarshalJSON() ([]byte, error) {
+	type Alias DisplayRenderTemplateDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// HintDirective sends a hint suggesting an utterance to the user. The hint is shown at the bottom of a template
+// rendered in the same response, and must be plain text.
+type HintDirective struct {
+	// Hint is the text of the hint
+	Hint *TextField `json:"hint"`
+}
+
+// NewHintDirective returns a HintDirective suggesting the utterance text
+func NewHintDirective(text string) *HintDirective {
+	return &HintDirective{Hint: NewPlainText(text)}
+}
+
+// GetType returns the HintDirectiveType
+func (directive *HintDirective) GetType() DirectiveType {
+	return HintDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the HintDirective type, setting the type field
+func (directive *HintDirective) MarshalJSON() ([]byte, error) {
+	type Alias HintDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// DisplayElementSelectedRequest is sent when the user selects a template, or an item in a list template, by touch.
+// Selections made by voice are sent as an IntentRequest instead.
+type DisplayElementSelectedRequest struct {
+	*BaseRequestType
+
+	// Token is the token of the selected template or ListItem
+	Token string `json:"token"`
+}
+
+// GetType returns the DisplayElementSelectedRequestType
+func (request *DisplayElementSelectedRequest) GetType() RequestTypeName {
+	return DisplayElementSelectedRequestType
+}
@@ -0,0 +1,133 @@
+package alexa
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDisplayRenderTemplateDirectiveMarshal(t *testing.T) {
+	Convey(`Given a body template with an image and rich text`, t, func() {
+		directive := &DisplayRenderTemplateDirective{
+			Template: &DisplayTemplate{
+				Type:       BodyTemplate2Type,
+				Token:      `recipe`,
+				BackButton: BackButtonHidden,
+				Title:      `Pad Thai`,
+				Image: &Image{
+					ContentDescription: `A bowl of noodles`,
+					Sources:            []*ImageSource{{URL: `https://example.com/pad-thai.png`, Size: ImageSizeSmall}},
+				},
+				TextContent: &TextContent{
+					PrimaryText:   NewRichText(`<b>Serves 2</b>`),
+					SecondaryText: NewPlainText(`20 minutes`),
+				},
+			},
+		}
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+
+			Convey(`Then there will be no error`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the template will be set and empty fields omitted`, func() {
+				So(string(data), ShouldEqual, `{"type":"Display.RenderTemplate","template":{"type":"BodyTemplate2",`+
+					`"token":"recipe","backButton":"HIDDEN","title":"Pad Thai",`+
+					`"image":{"contentDescription":"A bowl of noodles","sources":[{"url":"https://example.com/pad-thai.png","size":"SMALL"}]},`+
+					`"textContent":{"primaryText":{"type":"RichText","text":"\u003cb\u003eServes 2\u003c/b\u003e"},`+
+					`"secondaryText":{"type":"PlainText","text":"20 minutes"}}}}`)
+			})
+		})
+	})
+
+	Convey(`Given a list template`, t, func() {
+		directive := &DisplayRenderTemplateDirective{
+			Template: &DisplayTemplate{
+				Type:  ListTemplate1Type,
+				Token: `menu`,
+				ListItems: []*ListItem{
+					{Token: `dish-1`, TextContent: &TextContent{PrimaryText: NewPlainText(`Pad Thai`)}},
+					{Token: `dish-2`, TextContent: &TextContent{PrimaryText: NewPlainText(`Green Curry`)}},
+				},
+			},
+		}
+
+		Convey(`When I marshal it to JSON`, func() {
+			data, err := json.Marshal(directive)
+			So(err, ShouldBeNil)
+
+			Convey(`Then the list items will be set with their tokens`, func() {
+				So(string(data), ShouldEqual, `{"type":"Display.RenderTemplate","template":{"type":"ListTemplate1",`+
+					`"token":"menu","listItems":[`+
+					`{"token":"dish-1","textContent":{"primaryText":{"type":"PlainText","text":"Pad Thai"}}},`+
+					`{"token":"dish-2","textContent":{"primaryText":{"type":"PlainText","text":"Green Curry"}}}]}}`)
+			})
+		})
+	})
+}
+
+func TestHintDirectiveMarshal(t *testing.T) {
+	Convey(`When I marshal a hint directive to JSON`, t, func() {
+		data, err := json.Marshal(NewHintDirective(`show me the menu`))
+
+		Convey(`Then the hint will be plain text`, func() {
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `{"type":"Hint","hint":{"type":"PlainText","text":"show me the menu"}}`)
+		})
+	})
+}
+
+func TestDisplayElementSelectedRequestUnmarshal(t *testing.T) {
+	Convey(`When I unmarshal the Display.ElementSelected JSON into a Request struct`, t, func() {
+		request := &Request{}
+		if err := json.Unmarshal(displayElementSelectedRequestJSON, request); err != nil {
+			panic(err)
+		}
+
+		Convey(`Then the Request type will be correct`, func() {
+			So(request.Request, ShouldHaveSameTypeAs, &DisplayElementSelectedRequest{})
+			So(request.Request.GetType(), ShouldEqual, DisplayElementSelectedRequestType)
+		})
+
+		Convey(`Then the selected token will be set`, func() {
+			So(request.Request.(*DisplayElementSelectedRequest).Token, ShouldEqual, `dish-2`)
+		})
+
+		Convey(`Then the Session will be set`, func() {
+			So(request.Session, ShouldNotBeNil)
+		})
+	})
+}
+
+var displayElementSelectedRequestJSON = []byte(`
+{
+	"version": "1.0",
+	"session": {
+		"new": false,
+		"sessionId": "amzn1.echo-api.session.1",
+		"application": {
+			"applicationId": "amzn1.ask.skill.1"
+		},
+		"user": {
+			"userId": "amzn1.ask.account.1"
+		}
+	},
+	"context": {
+		"System": {
+			"application": {
+				"applicationId": "amzn1.ask.skill.1"
+			}
+		}
+	},
+	"request": {
+		"type": "Display.ElementSelected",
+		"requestId": "amzn1.echo-api.request.3d1a7c5e",
+		"timestamp": "2017-08-01T15:03:44Z",
+		"locale": "en-GB",
+		"token": "dish-2"
+	}
+}
+`)
@@ -1,64 +1,38 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"math/rand"
 	"net/http"
 
 	"github.com/tyndyll/alexa"
 )
 
-func RollDice(w http.ResponseWriter, r *http.Request) {
-	data, err := ioutil.ReadAll(r.Body)
-	if err != nil {
-		log.Println("Error reading HTTP body:", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
-	}
-
-	req := &alexa.Request{}
-
-	err = json.Unmarshal(data, req)
-	if err != nil {
-		log.Println("Error unmarshalling request:", err)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-		return
-	}
-
-	var response string
-
-	switch req.Request.GetType() {
-	case alexa.LaunchRequestType:
-		response = `What dice would you like to roll`
-	case alexa.IntentRequestType:
-		response = fmt.Sprintf("You rolled a %d", rand.Intn(99)+1)
-	}
+// speechResponse returns a Response which speaks text, shows it on a card and ends the session
+func speechResponse(text string) *alexa.Response {
+	return alexa.NewResponseBuilder().
+		Speak(text).
+		SimpleCard(``, text).
+		EndSession().
+		Build()
+}
 
-	alexaResponse := &alexa.Response{
-		Response: &alexa.ResponseData{
-			OutputSpeech: alexa.PlainSpeech(response),
-			Card: &alexa.Card{
-				Type: alexa.SimpleCardType,
-				Text: response,
-			},
-			ShouldEndSession: true,
-		},
-	}
+func Launch(ctx context.Context, req *alexa.Request) (*alexa.Response, error) {
+	return speechResponse(`What dice would you like to roll`), nil
+}
 
-	w.Header().Set(`Content-Type`, `application/json;charset=UTF-8`)
-	data, err = json.Marshal(alexaResponse)
-	if err != nil {
-		log.Println(`Problem Marshalling Response: `, err)
-	}
-	w.Write(data)
+func RollDice(ctx context.Context, req *alexa.Request) (*alexa.Response, error) {
+	return speechResponse(fmt.Sprintf("You rolled a %d", rand.Intn(99)+1)), nil
 }
 
 func main() {
-	//endpoint := alexa.RequestVerificationMiddleware(http.HandlerFunc(RollDice))
-	endpoint := http.HandlerFunc(RollDice)
+	skill := alexa.NewSkill()
+	skill.HandleRequestFunc(alexa.LaunchRequestType, Launch)
+	skill.HandleRequestFunc(alexa.IntentRequestType, RollDice)
+
+	//endpoint := alexa.NewVerifier().Middleware(skill)
+	endpoint := skill
 	http.Handle(`/`, endpoint)
 	http.ListenAndServe(":9000", nil)
 }
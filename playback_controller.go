@@ -0,0 +1,118 @@
+package alexa
+
+import (
+	"fmt"
+)
+
+const (
+	// PlaybackControllerPlayCommandIssuedRequestType indicates the user pressed the play button on a hardware
+	// controller or remote
+	PlaybackControllerPlayCommandIssuedRequestType RequestTypeName = `PlaybackController.PlayCommandIssued`
+	// PlaybackControllerPauseCommandIssuedRequestType indicates the user pressed the pause button on a hardware
+	// controller or remote
+	PlaybackControllerPauseCommandIssuedRequestType RequestTypeName = `PlaybackController.PauseCommandIssued`
+	// PlaybackControllerNextCommandIssuedRequestType indicates the user pressed the next button on a hardware
+	// controller or remote
+	PlaybackControllerNextCommandIssuedRequestType RequestTypeName = `PlaybackController.NextCommandIssued`
+	// PlaybackControllerPreviousCommandIssuedRequestType indicates the user pressed the previous button on a hardware
+	// controller or remote
+	PlaybackControllerPreviousCommandIssuedRequestType RequestTypeName = `PlaybackController.PreviousCommandIssued`
+)
+
+// PlaybackControllerPlayCommandIssuedRequest is sent when the user uses a play button on a device, such as a button on
+// a remote control, to resume playback.
+//
+// NOTE: PlaybackController requests are not sent in the context of a session, so Request.Session is nil. Your skill
+// can respond with AudioPlayer directives only, see ValidatePlaybackControllerResponse.
+type PlaybackControllerPlayCommandIssuedRequest struct {
+	*BaseRequestType
+}
+
+// GetType returns the PlaybackControllerPlayCommandIssuedRequestType
+func (request *PlaybackControllerPlayCommandIssuedRequest) GetType() RequestTypeName {
+	return PlaybackControllerPlayCommandIssuedRequestType
+}
+
+// PlaybackControllerPauseCommandIssuedRequest is sent when the user uses a pause button on a device, such as a button
+// on a remote control, to stop playback.
+//
+// NOTE: PlaybackController requests are not sent in the context of a session, so Request.Session is nil. Your skill
+// can respond with AudioPlayer directives only, see ValidatePlaybackControllerResponse.
+type PlaybackControllerPauseCommandIssuedRequest struct {
+	*BaseRequestType
+}
+
+// GetType returns the PlaybackControllerPauseCommandIssuedRequestType
+func (request *PlaybackControllerPauseCommandIssuedRequest) GetType() RequestTypeName {
+	return PlaybackControllerPauseCommandIssuedRequestType
+}
+
+// PlaybackControllerNextCommandIssuedRequest is sent when the user uses a next button on a device, such as a button on
+// a remote control.
+//
+// NOTE: PlaybackController requests are not sent in the context of a session, so Request.Session is nil. Your skill
+// can respond with AudioPlayer directives only, see ValidatePlaybackControllerResponse.
+type PlaybackControllerNextCommandIssuedRequest struct {
+	*BaseRequestType
+}
+
+// GetType returns the PlaybackControllerNextCommandIssuedRequestType
+func (request *PlaybackControllerNextCommandIssuedRequest) GetType() RequestTypeName {
+	return PlaybackControllerNextCommandIssuedRequestType
+}
+
+// PlaybackControllerPreviousCommandIssuedRequest is sent when the user uses a previous button on a device, such as a
+// button on a remote control.
+//
+// NOTE: PlaybackController requests are not sent in the context of a session, so Request.Session is nil. Your skill
+// can respond with AudioPlayer directives only, see ValidatePlaybackControllerResponse.
+type PlaybackControllerPreviousCommandIssuedRequest struct {
+	*BaseRequestType
+}
+
+// GetType returns the PlaybackControllerPreviousCommandIssuedRequestType
+func (request *PlaybackControllerPreviousCommandIssuedRequest) GetType() RequestTypeName {
+	return PlaybackControllerPreviousCommandIssuedRequestType
+}
+
+// IsPlaybackControllerRequest returns true if request is one of the PlaybackController requests
+func IsPlaybackControllerRequest(request RequestType) bool {
+	switch request.(type) {
+	case *PlaybackControllerPlayCommandIssuedRequest, *PlaybackControllerPauseCommandIssuedRequest,
+		*PlaybackControllerNextCommandIssuedRequest, *PlaybackControllerPreviousCommandIssuedRequest:
+		return true
+	}
+	return false
+}
+
+// ValidatePlaybackControllerResponse checks that response is a valid response to a PlaybackController request. Such a
+// response can only include AudioPlayer directives, and cannot include output speech, a card or a reprompt. A
+// *ResponseError describing the first invalid field is returned if the response is not valid. A nil response is valid.
+func ValidatePlaybackControllerResponse(response *Response) error {
+	if response == nil || response.Response == nil {
+		return nil
+	}
+
+	data := response.Response
+	if data.OutputSpeech != nil {
+		return &ResponseError{Field: "response.outputSpeech", Reason: "not permitted in response to a PlaybackController request"}
+	}
+	if data.Card != nil {
+		return &ResponseError{Field: "response.card", Reason: "not permitted in response to a PlaybackController request"}
+	}
+	if data.Reprompt != nil {
+		return &ResponseError{Field: "response.reprompt", Reason: "not permitted in response to a PlaybackController request"}
+	}
+
+	for i, directive := range data.Directives {
+		switch directive.(type) {
+		case *AudioPlayerPlayDirective, *AudioPlayerStopDirective, *AudioPlayerClearQueueDirective:
+		default:
+			return &ResponseError{
+				Field:  fmt.Sprintf("response.directives[%d]", i),
+				Reason: fmt.Sprintf("%s directive not permitted in response to a PlaybackController request", directive.GetType()),
+			}
+		}
+	}
+	return nil
+}
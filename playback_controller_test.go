@@ -0,0 +1,167 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// playbackControllerRequestJSON returns the JSON for a PlaybackController request of the given type
+func playbackControllerRequestJSON(requestType RequestTypeName) []byte {
+	return []byte(`
+{
+	"version": "1.0",
+	"context": {
+		"System": {
+			"application": {
+				"applicationId": "amzn1.ask.skill.1"
+			}
+		},
+		"AudioPlayer": {
+			"token": "track-1",
+			"offsetInMilliseconds": 1000,
+			"playerActivity": "PLAYING"
+		}
+	},
+	"request": {
+		"type": "` + string(requestType) + `",
+		"requestId": "amzn1.echo-api.request.7c4a2c49",
+		"timestamp": "2017-08-01T15:03:44Z",
+		"locale": "en-GB"
+	}
+}
+`)
+}
+
+func TestPlaybackControllerRequestUnmarshal(t *testing.T) {
+	requests := map[RequestTypeName]RequestType{
+		PlaybackControllerPlayCommandIssuedRequestType:     &PlaybackControllerPlayCommandIssuedRequest{},
+		PlaybackControllerPauseCommandIssuedRequestType:    &PlaybackControllerPauseCommandIssuedRequest{},
+		PlaybackControllerNextCommandIssuedRequestType:     &PlaybackControllerNextCommandIssuedRequest{},
+		PlaybackControllerPreviousCommandIssuedRequestType: &PlaybackControllerPreviousCommandIssuedRequest{},
+	}
+
+	for requestType, expected := range requests {
+		Convey(`When I unmarshal the `+string(requestType)+` JSON into a Request struct`, t, func() {
+			request := &Request{}
+			if err := json.Unmarshal(playbackControllerRequestJSON(requestType), request); err != nil {
+				panic(err)
+			}
+
+			Convey(`Then the Request type will be correct`, func() {
+				So(request.Request, ShouldHaveSameTypeAs, expected)
+				So(request.Request.GetType(), ShouldEqual, requestType)
+			})
+
+			Convey(`Then the Request will be a PlaybackController request`, func() {
+				So(IsPlaybackControllerRequest(request.Request), ShouldBeTrue)
+			})
+
+			Convey(`Then the Session will be nil`, func() {
+				So(request.Session, ShouldBeNil)
+			})
+
+			Convey(`Then the Context AudioPlayer will be set correctly`, func() {
+				So(request.Context.AudioPlayer.Token, ShouldEqual, `track-1`)
+			})
+		})
+	}
+}
+
+func TestValidatePlaybackControllerResponse(t *testing.T) {
+	Convey(`Given I have a response containing only AudioPlayer directives`, t, func() {
+		response := &Response{Response: &ResponseData{Directives: []Directive{&AudioPlayerStopDirective{}}}}
+
+		Convey(`When I call ValidatePlaybackControllerResponse`, func() {
+			err := ValidatePlaybackControllerResponse(response)
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey(`Given I have a response containing speech`, t, func() {
+		response := &Response{Response: &ResponseData{OutputSpeech: PlainSpeech(`Pausing`)}}
+
+		Convey(`When I call ValidatePlaybackControllerResponse`, func() {
+			err := ValidatePlaybackControllerResponse(response)
+
+			Convey(`Then the error will be a ResponseError for the output speech`, func() {
+				So(err, ShouldHaveSameTypeAs, &ResponseError{})
+				So(err.(*ResponseError).Field, ShouldEqual, `response.outputSpeech`)
+			})
+		})
+	})
+
+	Convey(`Given I have a response containing a card`, t, func() {
+		response := &Response{Response: &ResponseData{Card: &Card{Type: SimpleCardType}}}
+
+		Convey(`When I call ValidatePlaybackControllerResponse`, func() {
+			err := ValidatePlaybackControllerResponse(response)
+
+			Convey(`Then the error will be a ResponseError for the card`, func() {
+				So(err.(*ResponseError).Field, ShouldEqual, `response.card`)
+			})
+		})
+	})
+
+	Convey(`Given I have a nil response`, t, func() {
+		Convey(`When I call ValidatePlaybackControllerResponse`, func() {
+			err := ValidatePlaybackControllerResponse(nil)
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestSkill_PlaybackController(t *testing.T) {
+	Convey(`Given I have a Skill handling PlaybackController requests`, t, func() {
+		skill := NewSkill()
+		skill.HandleRequestFunc(PlaybackControllerPauseCommandIssuedRequestType, func(context.Context, *Request) (*Response, error) {
+			return &Response{Response: &ResponseData{Directives: []Directive{&AudioPlayerStopDirective{}}}}, nil
+		})
+		skill.HandleRequestFunc(PlaybackControllerNextCommandIssuedRequestType, testSpeechHandler(`next`))
+
+		Convey(`When I make a PauseCommandIssued request`, func() {
+			response := testServeSkill(skill, playbackControllerRequestJSON(PlaybackControllerPauseCommandIssuedRequestType))
+
+			Convey(`Then the status code will be StatusOK`, func() {
+				So(response.Code, ShouldEqual, http.StatusOK)
+			})
+
+			Convey(`Then the Stop directive will be written`, func() {
+				So(response.Body.String(), ShouldContainSubstring, `"directives":[{"type":"AudioPlayer.Stop"}]`)
+			})
+		})
+
+		Convey(`When I make a NextCommandIssued request to a handler responding with speech`, func() {
+			response := testServeSkill(skill, playbackControllerRequestJSON(PlaybackControllerNextCommandIssuedRequestType))
+
+			Convey(`Then the status code will be StatusInternalServerError`, func() {
+				So(response.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey(`And I set an apology error handler`, func() {
+			skill.HandleError(ApologyErrorHandler(`Sorry`))
+
+			Convey(`When I make a NextCommandIssued request to a handler responding with speech`, func() {
+				response := testServeSkill(skill, playbackControllerRequestJSON(PlaybackControllerNextCommandIssuedRequestType))
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the apology will not be spoken`, func() {
+					So(response.Body.String(), ShouldNotContainSubstring, `Sorry`)
+				})
+			})
+		})
+	})
+}
@@ -2,6 +2,7 @@ package alexa
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -35,6 +36,14 @@ type SupportedInterfaces string
 const (
 	// AudioPlayerSupported indicates the device supports streaming audio
 	AudioPlayerSupported SupportedInterfaces = `AudioPlayer`
+	// DisplaySupported indicates the device has a screen which supports Display templates
+	DisplaySupported SupportedInterfaces = `Display`
+	// VideoAppSupported indicates the device supports playing video
+	VideoAppSupported SupportedInterfaces = `VideoApp`
+	// APLSupported indicates the device has a screen which supports Alexa Presentation Language documents
+	APLSupported SupportedInterfaces = `Alexa.Presentation.APL`
+	// GeolocationSupported indicates the device can share its location with the skill
+	GeolocationSupported SupportedInterfaces = `Geolocation`
 	// AudioPlayerIdleState indicates nothing was playing, no enqueued items.
 	AudioPlayerIdleState AudioPlayerState = "IDLE"
 	// AudioPlayerPausedState indicates the stream was paused.
@@ -59,9 +68,13 @@ const (
 	// DialogStateCompleted indicates that dialog has has completed
 	DialogStateCompleted DialogState = "COMPLETED"
 
-	ResolutionStatusCodeMatch     ResolutionStatusCode = "ER_SUCCESS_MATCH"
-	ResolutionStatusCodeNoMatch   ResolutionStatusCode = "ER_SUCCESS_NO_MATCH"
-	ResolutionStatusCodeTimeout   ResolutionStatusCode = "ER_ERROR_TIMEOUT"
+	// ResolutionStatusCodeMatch indicates the spoken value matched a value
+	ResolutionStatusCodeMatch ResolutionStatusCode = "ER_SUCCESS_MATCH"
+	// ResolutionStatusCodeNoMatch indicates the spoken value did not match any value
+	ResolutionStatusCodeNoMatch ResolutionStatusCode = "ER_SUCCESS_NO_MATCH"
+	// ResolutionStatusCodeTimeout indicates the authority did not respond in time
+	ResolutionStatusCodeTimeout ResolutionStatusCode = "ER_ERROR_TIMEOUT"
+	// ResolutionStatusCodeException indicates the authority failed to resolve the value
 	ResolutionStatusCodeException ResolutionStatusCode = "ER_ERROR_EXCEPTION"
 
 	// SessionEndedReasonUserInitiated indicates the user explicitly ended the session.
@@ -94,8 +107,6 @@ const (
 	HelpRequestType RequestTypeName = `AMAZON.HelpIntent`
 	// StopRequestType is a build in request type indicating that the interaction is stopped
 	StopRequestType RequestTypeName = `AMAZON.StopIntent`
-
-	AudioPlayerRequestType = `AudioPlaterReq`
 )
 
 // AudioPlayer provides the current state for the AudioPlayer interface.
@@ -133,18 +144,29 @@ type BaseRequestType struct {
 	Locale string `json:"locale"`
 }
 
-// GetID returns the unique ID for the request
+// GetID returns the unique ID for the request. It is empty if the request did not include any of the common fields.
 func (request *BaseRequestType) GetID() string {
+	if request == nil {
+		return ""
+	}
 	return request.ID
 }
 
-// GetTimestamp returns the supplied time for the request
+// GetTimestamp returns the supplied time for the request. It is the zero time if the request did not include any of the
+// common fields.
 func (request *BaseRequestType) GetTimestamp() time.Time {
+	if request == nil {
+		return time.Time{}
+	}
 	return request.Timestamp
 }
 
-// GetLocale returns the supplied locale for the request
+// GetLocale returns the supplied locale for the request. It is empty if the request did not include any of the common
+// fields.
 func (request *BaseRequestType) GetLocale() string {
+	if request == nil {
+		return ""
+	}
 	return request.Locale
 }
 
@@ -170,6 +192,13 @@ type Context struct {
 	// AudioPlayer.PlaybackStarted indicates that the playback has started) and details about the state are part of the
 	// request object.
 	AudioPlayer *AudioPlayer `json:"AudioPlayer"`
+
+	// APL provides the state of the APL document rendered on the device. It is only included when the device supports
+	// the Alexa.Presentation.APL interface and is displaying a document rendered by the skill.
+	APL *APLContext `json:"Alexa.Presentation.APL"`
+
+	// Viewport describes the screen of the device. It is only included when the device has a screen.
+	Viewport *Viewport `json:"Viewport"`
 }
 
 // Device provides information about the device used to send a request.
@@ -179,20 +208,22 @@ type Device struct {
 
 	// SupportedInterfaces lists each interface that the device supports. For example, if SupportedInterfaces includes
 	// the key AudioPlayerSupported, then you know that the device supports streaming audio using the Alexa AudioPlayer
-	// interface.
+	// interface. Use the Supports method, or helpers such as HasAPLSupport, to check for an interface.
 	SupportedInterfaces map[SupportedInterfaces]interface{}
 }
 
+// Intent represents what the user wants to perform. An Intent received in an IntentRequest may be modified and returned
+// as the updated intent of a Dialog directive.
 type Intent struct {
 	// Name represents the name of the intent. It is set in the Alexa Developer console
 	Name string `json:"name"`
 
 	// ConfirmationStatus indicates whether the user has explicitly confirmed or denied the entire intent.
-	ConfirmationStatus ConfirmationStatusState `json:"confirmationStatus"`
+	ConfirmationStatus ConfirmationStatusState `json:"confirmationStatus,omitempty"`
 
 	// Slots is a map of key-value pairs that further describes what the user meant based on a predefined intent schema.
 	// The map can be empty.
-	Slots map[string]*Slot `json:"slots"`
+	Slots map[string]*Slot `json:"slots,omitempty"`
 }
 
 // IntentRequest is an object that represents a request a user makes to a skill that maps to an intent. The request
@@ -222,7 +253,7 @@ type Intent struct {
 //
 // If you are using the skill builder (beta) and you have created a dialog model, the IntentRequest includes a
 // DialogState property. You can use this to determine the current status of the conversation with the user and return
-// the Dialog.Delegate directive if the conversation is not yet complete.
+// the Dialog.Delegate directive if the conversation is not yet complete, see NewDelegateResponse.
 type IntentRequest struct {
 	*BaseRequestType
 
@@ -286,10 +317,15 @@ type Request struct {
 	// * IntentRequest
 	// * SessionEndedRequest
 	// * AudioPlayer Requests
-	// * Display.RenderTemplate Requests
 	// * Display.ElementSelected Requests
-	// * VideoApp Requests
+	// * Alexa.Presentation.APL.UserEvent Requests
 	// * PlaybackController Requests
+	// * System.ExceptionEncountered Requests
+	//
+	// Requests of any other type are decoded as an UnknownRequest.
+	//
+	// The VideoApp interface does not send requests of its own; a skill can check for VideoApp support with the
+	// Device HasVideoAppSupport method before returning a VideoAppLaunchDirective.
 	Request RequestType
 }
 
@@ -326,11 +362,55 @@ func (request *Request) determineRequestType(b []byte) error {
 		request.Request = &IntentRequest{}
 	case SessionEndedRequestType:
 		request.Request = &SessionEndedRequest{}
+	case AudioPlayerPlaybackStartedRequestType:
+		request.Request = &AudioPlayerPlaybackStartedRequest{}
+	case AudioPlayerPlaybackFinishedRequestType:
+		request.Request = &AudioPlayerPlaybackFinishedRequest{}
+	case AudioPlayerPlaybackStoppedRequestType:
+		request.Request = &AudioPlayerPlaybackStoppedRequest{}
+	case AudioPlayerPlaybackNearlyFinishedRequestType:
+		request.Request = &AudioPlayerPlaybackNearlyFinishedRequest{}
+	case AudioPlayerPlaybackFailedRequestType:
+		request.Request = &AudioPlayerPlaybackFailedRequest{}
+	case PlaybackControllerPlayCommandIssuedRequestType:
+		request.Request = &PlaybackControllerPlayCommandIssuedRequest{}
+	case PlaybackControllerPauseCommandIssuedRequestType:
+		request.Request = &PlaybackControllerPauseCommandIssuedRequest{}
+	case PlaybackControllerNextCommandIssuedRequestType:
+		request.Request = &PlaybackControllerNextCommandIssuedRequest{}
+	case PlaybackControllerPreviousCommandIssuedRequestType:
+		request.Request = &PlaybackControllerPreviousCommandIssuedRequest{}
+	case DisplayElementSelectedRequestType:
+		request.Request = &DisplayElementSelectedRequest{}
+	case APLUserEventRequestType:
+		request.Request = &APLUserEventRequest{}
+	case SystemExceptionEncounteredRequestType:
+		request.Request = &SystemExceptionEncounteredRequest{}
+	default:
+		request.Request = &UnknownRequest{Raw: append(json.RawMessage(nil), b...)}
 	}
 
 	return json.Unmarshal(b, request.Request)
 }
 
+// UnknownRequest holds a request whose type is not otherwise known to the package, such as a request from an interface
+// added to Alexa after this package was written. The common request fields are decoded, and the full request object
+// is kept in Raw so that a handler can decode the remaining fields itself.
+type UnknownRequest struct {
+	*BaseRequestType
+
+	// Type is the request type sent by Alexa
+	Type RequestTypeName `json:"type"`
+
+	// Raw is the undecoded request object
+	Raw json.RawMessage `json:"-"`
+}
+
+// GetType returns the request type sent by Alexa
+func (request *UnknownRequest) GetType() RequestTypeName {
+	return request.Type
+}
+
 // RequestSession provides additional context associated with a request. Standard request types (LaunchRequest,
 // IntentRequest, and SessionEndedRequest) include the session object.
 //
@@ -390,6 +470,19 @@ func (session *RequestSession) UnmarshalJSON(b []byte) error {
 //   * LaunchIntent
 //   * IntentRequest
 //   * SessionEndedRequest
+//   * AudioPlayerPlaybackStartedRequest
+//   * AudioPlayerPlaybackFinishedRequest
+//   * AudioPlayerPlaybackStoppedRequest
+//   * AudioPlayerPlaybackNearlyFinishedRequest
+//   * AudioPlayerPlaybackFailedRequest
+//   * PlaybackControllerPlayCommandIssuedRequest
+//   * PlaybackControllerPauseCommandIssuedRequest
+//   * PlaybackControllerNextCommandIssuedRequest
+//   * PlaybackControllerPreviousCommandIssuedRequest
+//   * DisplayElementSelectedRequest
+//   * APLUserEventRequest
+//   * SystemExceptionEncounteredRequest
+//   * UnknownRequest, for any other request type
 type RequestType interface {
 	GetType() RequestTypeName
 	GetID() string
@@ -405,19 +498,71 @@ type Resolution struct {
 	Authorities []*ResolutionAuthority `json:"resolutionsPerAuthority"`
 }
 
+// Dynamic returns the authority holding the results of resolving the slot against the dynamic entities set with a
+// Dialog.UpdateDynamicEntities directive, or nil if there is none
+func (resolution *Resolution) Dynamic() *ResolutionAuthority {
+	return resolution.authority(true)
+}
+
+// Static returns the authority holding the results of resolving the slot against the slot values defined in the
+// interaction model, or nil if there is none
+func (resolution *Resolution) Static() *ResolutionAuthority {
+	return resolution.authority(false)
+}
+
+func (resolution *Resolution) authority(dynamic bool) *ResolutionAuthority {
+	if resolution == nil {
+		return nil
+	}
+	for _, authority := range resolution.Authorities {
+		if authority != nil && authority.IsDynamic() == dynamic {
+			return authority
+		}
+	}
+	return nil
+}
+
+// dynamicAuthorityPrefix is the prefix of the name of the authority which resolves slot values against dynamic
+// entities
+const dynamicAuthorityPrefix = `amzn1.er-authority.echo-sdk.dynamic.`
+
+// ResolutionAuthority is the result of resolving a slot value against a single source of slot values. The source is
+// identified by Name, see IsDynamic.
 type ResolutionAuthority struct {
-	Name   string `json:"authority"`
-	Status *struct {
-		Code ResolutionStatusCode `json:"code"`
-	} `json:"status"`
+	Name   string             `json:"authority"`
+	Status *ResolutionStatus  `json:"status"`
 	Values []*ResolutionValue `json:"values"`
 }
 
+// ResolutionStatus indicates the result of resolving a slot value against an authority
+type ResolutionStatus struct {
+	Code ResolutionStatusCode `json:"code"`
+}
+
+// IsDynamic reports whether the authority resolved the slot value against dynamic entities rather than the slot values
+// defined in the interaction model
+func (authority *ResolutionAuthority) IsDynamic() bool {
+	return strings.HasPrefix(authority.Name, dynamicAuthorityPrefix)
+}
+
+// IsMatch reports whether the authority resolved the slot value to at least one value
+func (authority *ResolutionAuthority) IsMatch() bool {
+	return authority != nil && authority.Status != nil && authority.Status.Code == ResolutionStatusCodeMatch &&
+		len(authority.Values) > 0
+}
+
+// ResolutionValue is a value the slot has been resolved to
 type ResolutionValue struct {
-	Value *struct {
-		ID   string `json:"id"`
-		Name string `json:"name"`
-	} `json:"value"`
+	Value *ResolvedValue `json:"value"`
+}
+
+// ResolvedValue is the canonical value a slot value was resolved to
+type ResolvedValue struct {
+	// ID is the unique identifier of the value, as defined in the interaction model or dynamic entity
+	ID string `json:"id"`
+
+	// Name is the canonical name of the value
+	Name string `json:"name"`
 }
 
 // SessionEndedRequest is an object that represents a request made to an Alexa skill to notify that a session was ended.
@@ -449,6 +594,7 @@ type SessionError struct {
 	Message string           `json:"message"`
 }
 
+// Slot is an argument to an Intent which collects additional information needed to fulfill the user’s request
 type Slot struct {
 	// Name represents the name of the slot.
 	Name string `json:"name"`
@@ -457,16 +603,20 @@ type Slot struct {
 	// the canonical value or one of the synonyms defined for the entity.
 	//
 	// NOTE: AMAZON.LITERAL slot values sent to your service are always in all lower case.
-	Value string `json:"value"`
+	Value string `json:"value,omitempty"`
 
 	// ConfirmationStatus indicates whether the user has explicitly confirmed or denied the value of this slot.
-	ConfirmationStatus ConfirmationStatusState `json:"confirmationStatus"`
+	ConfirmationStatus ConfirmationStatusState `json:"confirmationStatus,omitempty"`
 
 	// Resolutions represents the results of resolving the words captured from the user’s utterance.
 	//
 	// This is included for slots that use a custom slot type or a built-in slot type that you have extended with your
 	// own values. Note that resolutions is not included for built-in slot types that you have not extended.
-	Resolutions *Resolution `json:"resolutions"`
+	Resolutions *Resolution `json:"resolutions,omitempty"`
+
+	// SlotValue holds the value or values the user spoke for the slot. Slots configured to accept multiple values are
+	// only reported here, see the Values method.
+	SlotValue *SlotValue `json:"slotValue,omitempty"`
 }
 
 // System provides information about the current state of the Alexa service and the device interacting with your skill.
@@ -529,12 +679,3 @@ type UserPermission struct {
 	// ConsentToken is a provided token for accessing customer information
 	ConsentToken string `json:"consentToken"`
 }
-
-/*
-
-func (d *Device) HasAudioPlayerSupport() bool {
-	_, hasSupport := d.SupportedInterfaces[audioPlayerSupported]
-	return hasSupport
-}
-
-*/
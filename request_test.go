@@ -115,6 +115,51 @@ func TestRequestUnmarshal(t *testing.T) {
 	})
 }
 
+func TestResolutionAuthorities(t *testing.T) {
+	Convey(`Given a slot resolved by both the dynamic and static authorities`, t, func() {
+		slot := &Slot{}
+		if err := json.Unmarshal(resolvedSlotJSON, slot); err != nil {
+			panic(err)
+		}
+
+		Convey(`When I get the dynamic authority`, func() {
+			authority := slot.Resolutions.Dynamic()
+
+			Convey(`Then it will be the dynamic authority`, func() {
+				So(authority, ShouldNotBeNil)
+				So(authority.IsDynamic(), ShouldBeTrue)
+			})
+
+			Convey(`Then it will have matched`, func() {
+				So(authority.IsMatch(), ShouldBeTrue)
+				So(authority.Values[0].Value.ID, ShouldEqual, `dish-1`)
+			})
+		})
+
+		Convey(`When I get the static authority`, func() {
+			authority := slot.Resolutions.Static()
+
+			Convey(`Then it will be the static authority`, func() {
+				So(authority, ShouldNotBeNil)
+				So(authority.IsDynamic(), ShouldBeFalse)
+			})
+
+			Convey(`Then it will not have matched`, func() {
+				So(authority.IsMatch(), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey(`Given a slot without resolutions`, t, func() {
+		slot := &Slot{Name: `dish`}
+
+		Convey(`Then there will be no dynamic or static authority`, func() {
+			So(slot.Resolutions.Dynamic(), ShouldBeNil)
+			So(slot.Resolutions.Static(), ShouldBeNil)
+		})
+	})
+}
+
 var launchRequestJSON = []byte(`
 {
 	"version": "1.0",
@@ -196,3 +241,22 @@ var sessionEndedRequestJSON = []byte(`
 	}
 }
 `)
+
+var resolvedSlotJSON = []byte(`
+{
+  "name": "dish",
+  "value": "noodles",
+  "resolutions": {
+    "resolutionsPerAuthority": [
+      {
+        "authority": "amzn1.er-authority.echo-sdk.dynamic.amzn1.ask.skill.1234.Dish",
+        "status": {"code": "ER_SUCCESS_MATCH"},
+        "values": [{"value": {"name": "pad thai", "id": "dish-1"}}]
+      },
+      {
+        "authority": "amzn1.er-authority.echo-sdk.amzn1.ask.skill.1234.Dish",
+        "status": {"code": "ER_SUCCESS_NO_MATCH"}
+      }
+    ]
+  }
+}`)
@@ -2,6 +2,10 @@ package alexa
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type CardType string
@@ -14,11 +18,83 @@ const (
 	// LinkAccountCardType is a card that displays a link to an authorization URL that the user can use to link their
 	// Alexa account with a user in another system
 	LinkAccountCardType CardType = `LinkAccount`
+	// AskForPermissionsConsentCardType is a card that asks the user to grant the skill access to their information,
+	// such as their address
+	AskForPermissionsConsentCardType CardType = `AskForPermissionsConsent`
+)
+
+// PermissionScope is a permission a skill can ask the user to grant with an AskForPermissionsConsent card
+type PermissionScope string
+
+const (
+	// PermissionScopeFullAddress allows the skill to read the full address of the device
+	PermissionScopeFullAddress PermissionScope = `read::alexa:device:all:address`
+	// PermissionScopeCountryAndPostalCode allows the skill to read the country and postal code of the device
+	PermissionScopeCountryAndPostalCode PermissionScope = `read::alexa:device:all:address:country_and_postal_code`
+	// PermissionScopeName allows the skill to read the full name of the user
+	PermissionScopeName PermissionScope = `alexa::profile:name:read`
+	// PermissionScopeGivenName allows the skill to read the given name of the user
+	PermissionScopeGivenName PermissionScope = `alexa::profile:given_name:read`
+	// PermissionScopeEmail allows the skill to read the email address of the user
+	PermissionScopeEmail PermissionScope = `alexa::profile:email:read`
+	// PermissionScopeMobileNumber allows the skill to read the mobile number of the user
+	PermissionScopeMobileNumber PermissionScope = `alexa::profile:mobile_number:read`
+	// PermissionScopeReminders allows the skill to create and manage reminders
+	PermissionScopeReminders PermissionScope = `alexa::alerts:reminders:skill:readwrite`
+	// PermissionScopeTimers allows the skill to create and manage timers
+	PermissionScopeTimers PermissionScope = `alexa::alerts:timers:skill:readwrite`
+	// PermissionScopeGeolocation allows the skill to read the location of the device
+	PermissionScopeGeolocation PermissionScope = `alexa::devices:all:geolocation:read`
+	// PermissionScopeListsRead allows the skill to read the lists of the user
+	PermissionScopeListsRead PermissionScope = `read::alexa:household:list`
+	// PermissionScopeListsWrite allows the skill to add to and change the lists of the user
+	PermissionScopeListsWrite PermissionScope = `write::alexa:household:list`
+)
+
+const (
+	// MaxResponseSize is the maximum size in bytes of a Response marshalled to JSON
+	MaxResponseSize = 24 * 1024
+	// MaxSpeechLength is the maximum number of characters in the text or SSML of an OutputSpeech
+	MaxSpeechLength = 8000
+	// MaxCardLength is the maximum number of characters in all of the text of a Card, including its image URLs
+	MaxCardLength = 8000
+	// MaxImageURLLength is the maximum number of characters in a Card image URL
+	MaxImageURLLength = 2000
 )
 
 // CardTypeDoesNotExist is an error returned when the output card has been set to an unknown CardType
 var CardTypeDoesNotExist error
 
+// ResponseError describes a field of a Response which is not valid
+type ResponseError struct {
+	// Field is the path to the invalid field in the JSON response, e.g. response.outputSpeech. It is empty when the
+	// Response as a whole is not valid
+	Field string
+
+	// Reason describes why the field is not valid
+	Reason string
+}
+
+// Error implements the error interface for the ResponseError type
+func (err *ResponseError) Error() string {
+	if err.Field == "" {
+		return err.Reason
+	}
+	return fmt.Sprintf("%s: %s", err.Field, err.Reason)
+}
+
+// ResponseErrors is returned by Response Validate, listing every field of the Response which is not valid
+type ResponseErrors []*ResponseError
+
+// Error implements the error interface for the ResponseErrors type
+func (errs ResponseErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
 // OutputSpeech is an interface used to return the text to be spoken in the Response OutputSpeech and Reprompt fields.
 //
 // Types which implement this interface include PlainSpeech, a plain string containing the speech to render to the user,
@@ -40,8 +116,8 @@ func (t PlainSpeech) MarshalJSON() ([]byte, error) {
 	}{"PlainText", string(t)})
 }
 
-// SSMLSpeech is a string containing text marked up with SSML to be spoken. The type performs no validation on the
-// passed SSML
+// SSMLSpeech is a string containing text marked up with SSML to be spoken. The SSML is not validated when it is
+// marshalled; use Validate to check it, or SSMLBuilder to build SSML which is well formed.
 type SSMLSpeech string
 
 // MarshalJSON converts the SSMLSpeech string into a JSON object, setting the type field and naming the output field
@@ -75,6 +151,9 @@ type Card struct {
 
 	// SmallImageURL is a string that specifies the URLs for a small image to display on a Standard card.
 	SmallImageURL string
+
+	// Permissions lists the permission scopes requested by an AskForPermissionsConsent card. At least one is required
+	Permissions []PermissionScope
 }
 
 // MarshalJSON implements the json.Marshaler interface for the Card type. It selects the appropriate CardType and
@@ -97,6 +176,11 @@ func (card *Card) MarshalJSON() (data []byte, err error) {
 			Small string `json:"smallImageUrl,omitempty"`
 		}
 
+		var images *Images
+		if card.LargeImageURL != "" || card.SmallImageURL != "" {
+			images = &Images{card.LargeImageURL, card.SmallImageURL}
+		}
+
 		data, err = json.Marshal(&struct {
 			Type  string  `json:"type"`
 			Title string  `json:"title"`
@@ -106,12 +190,20 @@ func (card *Card) MarshalJSON() (data []byte, err error) {
 			string(StandardCardType),
 			card.Title,
 			card.Text,
-			&Images{card.LargeImageURL, card.SmallImageURL},
+			images,
 		})
 	case LinkAccountCardType:
 		data, err = json.Marshal(&struct {
 			Type string `json:"type"`
 		}{string(LinkAccountCardType)})
+	case AskForPermissionsConsentCardType:
+		data, err = json.Marshal(&struct {
+			Type        string            `json:"type"`
+			Permissions []PermissionScope `json:"permissions"`
+		}{
+			string(AskForPermissionsConsentCardType),
+			card.Permissions,
+		})
 	default:
 		err = CardTypeDoesNotExist
 	}
@@ -123,7 +215,7 @@ type Response struct {
 	// SessionAttributes is a map of key-value pairs to persist in the session
 	// Session attributes are ignored by the Alexa service if they are included on a response to an AudioPlayer or
 	// a PlaybackController request.
-	SessionAttributes map[string]interface{} `json:"sessionAttributes"`
+	SessionAttributes map[string]interface{} `json:"sessionAttributes,omitempty"`
 
 	// Response defines what to render to the user and whether to end the current session
 	Response *ResponseData `json:"response"`
@@ -132,7 +224,7 @@ type Response struct {
 // MarshalJSON implements the json.Marshaler interface for the Response type. It selects the appropriate Response type
 // and populates the version field
 //
-// The total size of your response cannot exceed 24 kilobytes
+// The total size of your response cannot exceed 24 kilobytes, see Validate
 func (response *Response) MarshalJSON() (data []byte, err error) {
 	type Alias Response
 	return json.Marshal(&struct {
@@ -155,22 +247,190 @@ type ResponseData struct {
 
 	// Card contains a card to render to the Amazon Alexa App. All of the text included in a card cannot exceed 8000
 	// characters. This includes the title, content, text, and image URLs.
-	Card *Card `json:"card,omintempty"`
+	Card *Card `json:"card,omitempty"`
+
+	// Reprompt contains the speech to render to the user if the session is left open and the user does not respond or
+	// says something that is not understood. It is wrapped in an outputSpeech object when marshalled.
+	Reprompt OutputSpeech `json:"-"`
+
+	// ShouldEndSession indicates whether the session ends after the response. When it is nil the field is omitted, and
+	// the device decides: voice only devices end the session, while devices with a screen keep it open without
+	// listening for the user. Use Bool to set it in a struct literal.
+	//
+	// NOTE: ShouldEndSession was previously a bool which was always sent. A struct literal which does not set it used
+	// to send false, keeping the session open, but now omits the field so that voice only devices end the session. Set
+	// it to Bool(false) to keep the previous behaviour.
+	ShouldEndSession *bool `json:"shouldEndSession,omitempty"`
+
+	// Directives contains the directives specifying device-level actions to take using a particular interface, such as
+	// the AudioPlayer interface for streaming audio.
+	Directives []Directive `json:"directives,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface for the ResponseData type. Fields which are not set are omitted,
+// and the Reprompt is wrapped in the outputSpeech object Alexa expects.
+func (data *ResponseData) MarshalJSON() ([]byte, error) {
+	type Reprompt struct {
+		OutputSpeech OutputSpeech `json:"outputSpeech"`
+	}
 
-	Reprompt         OutputSpeech `json:"reprompt"`
-	ShouldEndSession bool         `json:"shouldEndSession"`
+	type Alias ResponseData
+	aux := &struct {
+		*Alias
+		Reprompt *Reprompt `json:"reprompt,omitempty"`
+	}{
+		Alias: (*Alias)(data),
+	}
+	if data.Reprompt != nil {
+		aux.Reprompt = &Reprompt{OutputSpeech: data.Reprompt}
+	}
+	return json.Marshal(aux)
 }
 
-type Directive struct{}
+// Bool returns a pointer to value, for setting ResponseData ShouldEndSession
+func Bool(value bool) *bool {
+	return &value
+}
 
 // NewPlainSpeechResponse is a utility function that takes the Output Speech to be delivered in the response, populates
-// it in a Response and then marshals that response into JSON
+// it in a Response which ends the session and then marshals that response into JSON. Use ResponseBuilder to build
+// other responses.
 func NewPlainSpeechResponse(outputSpeech string) ([]byte, error) {
-	response := &Response{
-		Response: &ResponseData{
-			OutputSpeech:     PlainSpeech(outputSpeech),
-			ShouldEndSession: true,
-		},
+	return json.Marshal(NewResponseBuilder().Speak(outputSpeech).EndSession().Build())
+}
+
+// Validate checks the Response against the limits Alexa places on a response:
+//   * The response cannot exceed MaxResponseSize bytes when marshalled to JSON
+//   * The output speech and reprompt cannot exceed MaxSpeechLength characters
+//   * The text of the card cannot exceed MaxCardLength characters, and its image URLs MaxImageURLLength characters
+//   * An AskForPermissionsConsent card must request at least one permission
+//
+// ResponseErrors listing every limit which was exceeded is returned if the Response is not valid. A nil Response is
+// valid.
+func (response *Response) Validate() error {
+	if response == nil {
+		return nil
+	}
+
+	var errs ResponseErrors
+	if data, err := json.Marshal(response); err != nil {
+		errs = append(errs, &ResponseError{Reason: fmt.Sprintf("cannot be marshalled: %s", err)})
+	} else if len(data) > MaxResponseSize {
+		errs = append(errs, &ResponseError{Reason: fmt.Sprintf("size %d bytes exceeds %d bytes", len(data), MaxResponseSize)})
+	}
+
+	if data := response.Response; data != nil {
+		errs = append(errs, validateSpeechLength("response.outputSpeech", data.OutputSpeech)...)
+		errs = append(errs, validateSpeechLength("response.reprompt.outputSpeech", data.Reprompt)...)
+		if data.Card != nil {
+			errs = append(errs, data.Card.validate("response.card")...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// TruncateSpeech shortens output speech and reprompt speech longer than MaxSpeechLength characters, cutting at the
+// end of the last sentence which fits. SSML is only shortened if the result remains valid SSML, otherwise it is left
+// for Validate to report.
+func (response *Response) TruncateSpeech() {
+	if response == nil || response.Response == nil {
+		return
+	}
+	response.Response.OutputSpeech = truncateSpeech(response.Response.OutputSpeech)
+	response.Response.Reprompt = truncateSpeech(response.Response.Reprompt)
+}
+
+// validate returns a ResponseError for each limit exceeded by the card, whose path in the JSON response is field
+func (card *Card) validate(field string) []*ResponseError {
+	var errs []*ResponseError
+	length := utf8.RuneCountInString(card.Title + card.Text + card.LargeImageURL + card.SmallImageURL)
+	if length > MaxCardLength {
+		errs = append(errs, &ResponseError{
+			Field:  field,
+			Reason: fmt.Sprintf("length %d characters exceeds %d characters", length, MaxCardLength),
+		})
+	}
+
+	if card.Type == AskForPermissionsConsentCardType && len(card.Permissions) == 0 {
+		errs = append(errs, &ResponseError{Field: field + ".permissions", Reason: "at least one permission is required"})
+	}
+
+	for _, image := range [][2]string{{"smallImageUrl", card.SmallImageURL}, {"largeImageUrl", card.LargeImageURL}} {
+		if length := utf8.RuneCountInString(image[1]); length > MaxImageURLLength {
+			errs = append(errs, &ResponseError{
+				Field:  field + ".image." + image[0],
+				Reason: fmt.Sprintf("length %d characters exceeds %d characters", length, MaxImageURLLength),
+			})
+		}
+	}
+	return errs
+}
+
+// validateSpeechLength returns a ResponseError if the text of speech, whose path in the JSON response is field, is
+// longer than MaxSpeechLength characters
+func validateSpeechLength(field string, speech OutputSpeech) []*ResponseError {
+	var text string
+	switch speech := speech.(type) {
+	case PlainSpeech:
+		text = string(speech)
+	case SSMLSpeech:
+		text = string(speech)
+	}
+
+	if length := utf8.RuneCountInString(text); length > MaxSpeechLength {
+		return []*ResponseError{{
+			Field:  field,
+			Reason: fmt.Sprintf("length %d characters exceeds %d characters", length, MaxSpeechLength),
+		}}
+	}
+	return nil
+}
+
+// truncateSpeech returns speech shortened to MaxSpeechLength characters at a sentence boundary
+func truncateSpeech(speech OutputSpeech) OutputSpeech {
+	switch speech := speech.(type) {
+	case PlainSpeech:
+		if utf8.RuneCountInString(string(speech)) > MaxSpeechLength {
+			return PlainSpeech(truncateAtSentence(string(speech), MaxSpeechLength))
+		}
+	case SSMLSpeech:
+		if utf8.RuneCountInString(string(speech)) <= MaxSpeechLength {
+			return speech
+		}
+		const closing = "</speak>"
+		body := strings.TrimSuffix(strings.TrimSpace(string(speech)), closing)
+		truncated := SSMLSpeech(truncateAtSentence(body, MaxSpeechLength-len(closing)) + closing)
+		if truncated.Validate() == nil {
+			return truncated
+		}
+	}
+	return speech
+}
+
+// truncateAtSentence returns text shortened to at most max characters, ending after the last full stop, question mark
+// or exclamation mark that fits. If no sentence fits, text is cut at the last space, or at max characters if there is
+// no space.
+func truncateAtSentence(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+
+	space := -1
+	for i := max; i > 0; i-- {
+		switch {
+		case strings.ContainsRune(".!?", runes[i-1]) && (i == len(runes) || unicode.IsSpace(runes[i])):
+			return string(runes[:i])
+		case space < 0 && unicode.IsSpace(runes[i]):
+			space = i
+		}
+	}
+	if space > 0 {
+		return strings.TrimRightFunc(string(runes[:space]), unicode.IsSpace)
 	}
-	return json.Marshal(response)
+	return string(runes[:max])
 }
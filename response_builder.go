@@ -0,0 +1,137 @@
+package alexa
+
+import (
+	"strings"
+)
+
+// ResponseBuilder builds a Response. Methods return the builder so that calls can be chained, for example:
+//
+//	response := alexa.NewResponseBuilder().
+//		Speak("What dice would you like to roll?").
+//		Reprompt("Which dice?").
+//		SimpleCard("Dice", "What dice would you like to roll?").
+//		KeepSessionOpen().
+//		Build()
+//
+// Unless EndSession or KeepSessionOpen is called, shouldEndSession is omitted and the device decides whether the
+// session ends, see ResponseData ShouldEndSession.
+type ResponseBuilder struct {
+	response *Response
+}
+
+// NewResponseBuilder returns a ResponseBuilder for an empty Response
+func NewResponseBuilder() *ResponseBuilder {
+	return &ResponseBuilder{
+		response: &Response{
+			Response: &ResponseData{},
+		},
+	}
+}
+
+// Speak sets the output speech to text, as plain text
+func (builder *ResponseBuilder) Speak(text string) *ResponseBuilder {
+	builder.response.Response.OutputSpeech = PlainSpeech(text)
+	return builder
+}
+
+// SpeakSSML sets the output speech to ssml, wrapping it in a speak element if it is not already
+func (builder *ResponseBuilder) SpeakSSML(ssml SSMLSpeech) *ResponseBuilder {
+	builder.response.Response.OutputSpeech = wrapSpeak(ssml)
+	return builder
+}
+
+// Reprompt sets the reprompt speech to text, as plain text
+func (builder *ResponseBuilder) Reprompt(text string) *ResponseBuilder {
+	builder.response.Response.Reprompt = PlainSpeech(text)
+	return builder
+}
+
+// RepromptSSML sets the reprompt speech to ssml, wrapping it in a speak element if it is not already
+func (builder *ResponseBuilder) RepromptSSML(ssml SSMLSpeech) *ResponseBuilder {
+	builder.response.Response.Reprompt = wrapSpeak(ssml)
+	return builder
+}
+
+// SimpleCard sets the card to a Simple card with title and content
+func (builder *ResponseBuilder) SimpleCard(title, content string) *ResponseBuilder {
+	builder.response.Response.Card = &Card{
+		Type:  SimpleCardType,
+		Title: title,
+		Text:  content,
+	}
+	return builder
+}
+
+// StandardCard sets the card to a Standard card with title, text and images. The image URLs are optional
+func (builder *ResponseBuilder) StandardCard(title, text, smallImageURL, largeImageURL string) *ResponseBuilder {
+	builder.response.Response.Card = &Card{
+		Type:          StandardCardType,
+		Title:         title,
+		Text:          text,
+		SmallImageURL: smallImageURL,
+		LargeImageURL: largeImageURL,
+	}
+	return builder
+}
+
+// LinkAccountCard sets the card to a LinkAccount card, asking the user to link their account
+func (builder *ResponseBuilder) LinkAccountCard() *ResponseBuilder {
+	builder.response.Response.Card = &Card{Type: LinkAccountCardType}
+	return builder
+}
+
+// AskForPermissions sets the card to an AskForPermissionsConsent card, asking the user to grant permissions
+func (builder *ResponseBuilder) AskForPermissions(permissions ...PermissionScope) *ResponseBuilder {
+	builder.response.Response.Card = &Card{
+		Type:        AskForPermissionsConsentCardType,
+		Permissions: permissions,
+	}
+	return builder
+}
+
+// AddDirective appends directive to the directives of the Response
+func (builder *ResponseBuilder) AddDirective(directive Directive) *ResponseBuilder {
+	builder.response.Response.Directives = append(builder.response.Response.Directives, directive)
+	return builder
+}
+
+// WithSessionAttribute sets the session attribute key to value
+func (builder *ResponseBuilder) WithSessionAttribute(key string, value interface{}) *ResponseBuilder {
+	if builder.response.SessionAttributes == nil {
+		builder.response.SessionAttributes = map[string]interface{}{}
+	}
+	builder.response.SessionAttributes[key] = value
+	return builder
+}
+
+// EndSession ends the session after the Response
+func (builder *ResponseBuilder) EndSession() *ResponseBuilder {
+	builder.response.Response.ShouldEndSession = Bool(true)
+	return builder
+}
+
+// KeepSessionOpen keeps the session open after the Response, listening for the user
+func (builder *ResponseBuilder) KeepSessionOpen() *ResponseBuilder {
+	builder.response.Response.ShouldEndSession = Bool(false)
+	return builder
+}
+
+// LeaveSessionToDevice omits shouldEndSession, leaving the device to decide whether the session ends. Devices with a
+// screen keep the session open without listening, which suits responses with a template or video
+func (builder *ResponseBuilder) LeaveSessionToDevice() *ResponseBuilder {
+	builder.response.Response.ShouldEndSession = nil
+	return builder
+}
+
+// Build returns the Response
+func (builder *ResponseBuilder) Build() *Response {
+	return builder.response
+}
+
+// wrapSpeak wraps ssml in a speak element if it is not already
+func wrapSpeak(ssml SSMLSpeech) SSMLSpeech {
+	if strings.HasPrefix(strings.TrimSpace(string(ssml)), "<speak>") {
+		return ssml
+	}
+	return "<speak>" + ssml + "</speak>"
+}
@@ -0,0 +1,87 @@
+package alexa
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestResponseBuilder(t *testing.T) {
+	Convey(`Given I build a Response with speech, a reprompt, a card and a session attribute`, t, func() {
+		response := NewResponseBuilder().
+			Speak(`What dice would you like to roll?`).
+			Reprompt(`Which dice?`).
+			SimpleCard(`Dice`, `Roll`).
+			WithSessionAttribute(`rolls`, 2).
+			KeepSessionOpen().
+			Build()
+
+		Convey(`Then the fields will be set`, func() {
+			So(response.Response.OutputSpeech, ShouldEqual, PlainSpeech(`What dice would you like to roll?`))
+			So(response.Response.Reprompt, ShouldEqual, PlainSpeech(`Which dice?`))
+			So(response.Response.Card, ShouldResemble, &Card{Type: SimpleCardType, Title: `Dice`, Text: `Roll`})
+			So(response.SessionAttributes, ShouldResemble, map[string]interface{}{`rolls`: 2})
+		})
+
+		Convey(`Then the session will be kept open`, func() {
+			So(response.Response.ShouldEndSession, ShouldResemble, Bool(false))
+		})
+	})
+
+	Convey(`Given I build a Response with SSML which is not wrapped in a speak element`, t, func() {
+		response := NewResponseBuilder().SpeakSSML(`Hello <break time="1s"/> there`).RepromptSSML(`<speak>Hello?</speak>`).Build()
+
+		Convey(`Then the output speech will be wrapped`, func() {
+			So(response.Response.OutputSpeech, ShouldEqual, SSMLSpeech(`<speak>Hello <break time="1s"/> there</speak>`))
+		})
+
+		Convey(`Then the reprompt will not be wrapped again`, func() {
+			So(response.Response.Reprompt, ShouldEqual, SSMLSpeech(`<speak>Hello?</speak>`))
+		})
+	})
+
+	Convey(`Given I build a Response with a standard card and directives`, t, func() {
+		response := NewResponseBuilder().
+			StandardCard(`Title`, `Text`, `https://example.com/small.png`, `https://example.com/large.png`).
+			AddDirective(&AudioPlayerStopDirective{}).
+			AddDirective(NewHintDirective(`roll a dice`)).
+			EndSession().
+			Build()
+
+		Convey(`Then the card and directives will be set`, func() {
+			So(response.Response.Card.SmallImageURL, ShouldEqual, `https://example.com/small.png`)
+			So(response.Response.Directives, ShouldHaveLength, 2)
+		})
+
+		Convey(`Then the session will end`, func() {
+			So(response.Response.ShouldEndSession, ShouldResemble, Bool(true))
+		})
+	})
+
+	Convey(`Given I build a Response and leave the session to the device`, t, func() {
+		response := NewResponseBuilder().Speak(`Here is the menu`).EndSession().LeaveSessionToDevice().Build()
+
+		Convey(`When I marshal it to JSON`, func() {
+			output, err := json.Marshal(response)
+			So(err, ShouldBeNil)
+
+			Convey(`Then shouldEndSession will be omitted`, func() {
+				So(string(output), ShouldNotContainSubstring, `shouldEndSession`)
+			})
+		})
+	})
+
+	Convey(`Given I build a Response asking for permissions`, t, func() {
+		response := NewResponseBuilder().LinkAccountCard().AskForPermissions(PermissionScopeFullAddress).Build()
+
+		Convey(`When I marshal the card to JSON`, func() {
+			output, err := json.Marshal(response.Response.Card)
+			So(err, ShouldBeNil)
+
+			Convey(`Then the permissions will be listed`, func() {
+				So(string(output), ShouldEqual, `{"type":"AskForPermissionsConsent","permissions":["read::alexa:device:all:address"]}`)
+			})
+		})
+	})
+}
@@ -2,6 +2,10 @@ package alexa
 
 import (
 	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -40,3 +44,214 @@ func TestSSMLSpeech_MarshalJSON(t *testing.T) {
 		})
 	})
 }
+
+func TestResponse_Validate(t *testing.T) {
+	Convey(`Given I have a Response within every limit`, t, func() {
+		response := &Response{Response: &ResponseData{
+			OutputSpeech: PlainSpeech(`Hello`),
+			Card:         &Card{Type: SimpleCardType, Title: `Hello`, Text: `World`},
+		}}
+
+		Convey(`Then it will be valid`, func() {
+			So(response.Validate(), ShouldBeNil)
+		})
+	})
+
+	Convey(`Given I have a Response exceeding several limits`, t, func() {
+		longURL := `https://example.com/` + strings.Repeat(`a`, MaxImageURLLength)
+		response := &Response{Response: &ResponseData{
+			OutputSpeech: PlainSpeech(strings.Repeat(`a`, MaxSpeechLength+1)),
+			Reprompt:     SSMLSpeech(`<speak>` + strings.Repeat(`b`, MaxSpeechLength) + `</speak>`),
+			Card: &Card{
+				Type:          StandardCardType,
+				Title:         `Title`,
+				Text:          strings.Repeat(`c`, MaxCardLength),
+				LargeImageURL: longURL,
+			},
+		}}
+
+		Convey(`When I validate it`, func() {
+			err := response.Validate()
+
+			Convey(`Then every violated limit will be reported with its field path`, func() {
+				So(err, ShouldHaveSameTypeAs, ResponseErrors{})
+				var fields []string
+				for _, fieldErr := range err.(ResponseErrors) {
+					fields = append(fields, fieldErr.Field)
+				}
+				So(fields, ShouldResemble, []string{
+					``,
+					`response.outputSpeech`,
+					`response.reprompt.outputSpeech`,
+					`response.card`,
+					`response.card.image.largeImageUrl`,
+				})
+			})
+
+			Convey(`Then the error message will include each reason`, func() {
+				So(err.Error(), ShouldContainSubstring, `response.outputSpeech: length 8001 characters exceeds 8000 characters`)
+				So(err.Error(), ShouldStartWith, `size `)
+			})
+		})
+	})
+}
+
+func TestResponse_ValidateAskForPermissionsConsentCard(t *testing.T) {
+	Convey(`Given I have a Response asking for permissions`, t, func() {
+		response := NewResponseBuilder().
+			Speak(`Please grant permission in the Alexa app`).
+			AskForPermissions(PermissionScopeGivenName, PermissionScopeGeolocation).
+			Build()
+
+		Convey(`Then it will be valid`, func() {
+			So(response.Validate(), ShouldBeNil)
+		})
+
+		Convey(`Then the scopes will be marshalled in the card`, func() {
+			output, err := json.Marshal(response)
+			So(err, ShouldBeNil)
+			So(string(output), ShouldContainSubstring, `"card":{"type":"AskForPermissionsConsent",`+
+				`"permissions":["alexa::profile:given_name:read","alexa::devices:all:geolocation:read"]}`)
+		})
+	})
+
+	Convey(`Given I have a Response asking for no permissions`, t, func() {
+		response := NewResponseBuilder().AskForPermissions().Build()
+
+		Convey(`Then the missing permissions will be reported`, func() {
+			So(response.Validate(), ShouldResemble, ResponseErrors{
+				{Field: `response.card.permissions`, Reason: `at least one permission is required`},
+			})
+		})
+	})
+}
+
+func TestResponse_TruncateSpeech(t *testing.T) {
+	sentence := `This is a sentence. `
+	long := strings.Repeat(sentence, MaxSpeechLength/len(sentence)+10)
+
+	Convey(`Given I have a Response with plain speech which is too long`, t, func() {
+		response := &Response{Response: &ResponseData{OutputSpeech: PlainSpeech(long)}}
+
+		Convey(`When I truncate the speech`, func() {
+			response.TruncateSpeech()
+			speech := string(response.Response.OutputSpeech.(PlainSpeech))
+
+			Convey(`Then it will fit within the limit`, func() {
+				So(len(speech), ShouldBeLessThanOrEqualTo, MaxSpeechLength)
+			})
+
+			Convey(`Then it will end at a sentence boundary`, func() {
+				So(speech, ShouldEndWith, `sentence.`)
+			})
+
+			Convey(`Then the response will be valid`, func() {
+				So(response.Validate(), ShouldBeNil)
+			})
+		})
+	})
+
+	Convey(`Given I have a Response with SSML reprompt speech which is too long`, t, func() {
+		response := &Response{Response: &ResponseData{Reprompt: SSMLSpeech(`<speak>` + long + `</speak>`)}}
+
+		Convey(`When I truncate the speech`, func() {
+			response.TruncateSpeech()
+			speech := response.Response.Reprompt.(SSMLSpeech)
+
+			Convey(`Then it will be valid SSML within the limit`, func() {
+				So(len(speech), ShouldBeLessThanOrEqualTo, MaxSpeechLength)
+				So(speech.Validate(), ShouldBeNil)
+				So(string(speech), ShouldEndWith, `sentence.</speak>`)
+			})
+		})
+	})
+
+	Convey(`Given I have speech without a sentence boundary`, t, func() {
+		Convey(`Then it will be cut at the last space`, func() {
+			So(truncateAtSentence(`one two three`, 9), ShouldEqual, `one two`)
+		})
+
+		Convey(`Then it will be cut at the limit if there is no space`, func() {
+			So(truncateAtSentence(`onetwothree`, 6), ShouldEqual, `onetwo`)
+		})
+	})
+}
+
+// updateGolden rewrites the golden files in testdata/responses with the current output when set
+var updateGolden = flag.Bool(`update`, false, `update the golden files in testdata/responses`)
+
+func TestResponse_MarshalJSONGolden(t *testing.T) {
+	responses := map[string]*Response{
+		`empty`: {Response: &ResponseData{}},
+		`plain_speech`: {Response: &ResponseData{
+			OutputSpeech:     PlainSpeech(`Hello`),
+			ShouldEndSession: Bool(true),
+		}},
+		`ssml_speech_reprompt`: {
+			SessionAttributes: map[string]interface{}{`count`: 1},
+			Response: &ResponseData{
+				OutputSpeech:     SSMLSpeech(`<speak>Hello</speak>`),
+				Reprompt:         PlainSpeech(`Are you still there?`),
+				ShouldEndSession: Bool(false),
+			},
+		},
+		`simple_card`: {Response: &ResponseData{
+			Card: &Card{Type: SimpleCardType, Title: `Title`, Text: `Content`},
+		}},
+		`standard_card_images`: {Response: &ResponseData{
+			Card: &Card{
+				Type:          StandardCardType,
+				Title:         `Title`,
+				Text:          `Text`,
+				SmallImageURL: `https://example.com/small.png`,
+				LargeImageURL: `https://example.com/large.png`,
+			},
+		}},
+		`standard_card_no_images`: {Response: &ResponseData{
+			Card: &Card{Type: StandardCardType, Title: `Title`, Text: `Text`},
+		}},
+		`ask_for_permissions_consent_card`: {Response: &ResponseData{
+			OutputSpeech: PlainSpeech(`Please grant permission in the Alexa app`),
+			Card: &Card{
+				Type: AskForPermissionsConsentCardType,
+				Permissions: []PermissionScope{
+					PermissionScopeFullAddress,
+					PermissionScopeEmail,
+					PermissionScopeReminders,
+					PermissionScopeListsRead,
+					PermissionScopeListsWrite,
+				},
+			},
+			ShouldEndSession: Bool(true),
+		}},
+		`link_account_card`: {Response: &ResponseData{
+			OutputSpeech: PlainSpeech(`Please link your account`),
+			Card:         &Card{Type: LinkAccountCardType},
+		}},
+		`directives`: {Response: &ResponseData{
+			Directives: []Directive{&AudioPlayerStopDirective{}, &DialogDelegateDirective{}},
+		}},
+	}
+
+	for name, response := range responses {
+		Convey(`When I marshal the `+name+` Response to JSON`, t, func() {
+			output, err := json.MarshalIndent(response, ``, `  `)
+			So(err, ShouldBeNil)
+
+			golden := filepath.Join(`testdata`, `responses`, name+`.json`)
+			if *updateGolden {
+				if err := ioutil.WriteFile(golden, append(output, '\n'), 0644); err != nil {
+					panic(err)
+				}
+			}
+			expected, err := ioutil.ReadFile(golden)
+			if err != nil {
+				panic(err)
+			}
+
+			Convey(`Then the JSON will match the golden file`, func() {
+				So(string(output)+"\n", ShouldEqual, string(expected))
+			})
+		})
+	}
+}
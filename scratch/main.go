@@ -1,14 +1,23 @@
 package main
 
 import (
+	"crypto"
+	"io/ioutil"
 	"log"
+	"os"
 
 	"github.com/tyndyll/alexa"
 )
 
 func main() {
+	// The body of the signed request is read from stdin
+	body, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
 	certURL := "https://s3.amazonaws.com/echo.api/echo-api-cert.pem"
-	if valid, err := alexa.ValidateCertificate(certURL, "RjwqmCJgXPJSUW+Zi8s1CzbfAoYO/TtaRl05zw+Hw5OO6jzMNGg/OKOAIhMo8wTVvoPSragaKUr0THii7ulrY50pwpSBmOR6fpYZDHemkQck4/DBCxIGrBVtsXra4geHkZ/1pwtlqLvgJzQIJ2FxCwWemkLwhOLW0ecphSFkXUR3PdDk+Dawt3GpJiLMpbh5B3Ge9ppx78RNcyzp8EAkXag8w9GeOxUGEpAddz/QgMH2NbTCsTR/E6agFNV0/vHm/+zQTVQDw9GIo38lw7VMZrFVFfQkSlYEqRMgGeV37iShMrhxCY5vSYvw0EKxRorHx+9djV8sC5E86hTf+X2xaQ=="); err != nil {
+	if valid, err := alexa.NewVerifier().ValidateCertificate(certURL, "RjwqmCJgXPJSUW+Zi8s1CzbfAoYO/TtaRl05zw+Hw5OO6jzMNGg/OKOAIhMo8wTVvoPSragaKUr0THii7ulrY50pwpSBmOR6fpYZDHemkQck4/DBCxIGrBVtsXra4geHkZ/1pwtlqLvgJzQIJ2FxCwWemkLwhOLW0ecphSFkXUR3PdDk+Dawt3GpJiLMpbh5B3Ge9ppx78RNcyzp8EAkXag8w9GeOxUGEpAddz/QgMH2NbTCsTR/E6agFNV0/vHm/+zQTVQDw9GIo38lw7VMZrFVFfQkSlYEqRMgGeV37iShMrhxCY5vSYvw0EKxRorHx+9djV8sC5E86hTf+X2xaQ==", crypto.SHA1, body); err != nil {
 		log.Fatalln(err)
 	} else {
 		if valid {
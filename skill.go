@@ -0,0 +1,329 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+)
+
+// Handler responds to an Alexa request. ServeAlexa returns the Response to send to Alexa, or an error if the request
+// could not be handled. A nil Response with a nil error results in an empty Response, which is appropriate for requests
+// such as SessionEndedRequest which cannot be responded to.
+type Handler interface {
+	ServeAlexa(ctx context.Context, request *Request) (*Response, error)
+}
+
+// HandlerFunc is an adapter to allow the use of ordinary functions as Alexa handlers
+type HandlerFunc func(ctx context.Context, request *Request) (*Response, error)
+
+// ServeAlexa calls f(ctx, request)
+func (f HandlerFunc) ServeAlexa(ctx context.Context, request *Request) (*Response, error) {
+	return f(ctx, request)
+}
+
+// RequestInterceptor is called by a Skill before a request is dispatched to its handler, for example to load user
+// state. The returned context is passed to later interceptors and the handler, and must not be nil. If a non-nil
+// Response is returned, the remaining request interceptors and the handler are skipped and that Response is used
+// instead. If an error is returned, the request is passed to the Skill error handler.
+type RequestInterceptor func(ctx context.Context, request *Request) (context.Context, *Response, error)
+
+// ResponseInterceptor is called by a Skill with the Response for a request before it is written, for example to
+// localize output or persist session attributes. If the handler returned a nil Response, the interceptors are passed an
+// empty Response. The returned Response replaces the Response for the request. If an error is returned, the remaining
+// response interceptors are skipped and the request is passed to the Skill error handler.
+type ResponseInterceptor func(ctx context.Context, request *Request, response *Response) (*Response, error)
+
+// ErrorHandler is called by a Skill when a request could not be handled, returning the Response to send to Alexa in
+// place of an error.
+type ErrorHandler func(ctx context.Context, request *Request, err error) *Response
+
+// ApologyErrorHandler returns an ErrorHandler which responds to every error by speaking apology and ending the session.
+// Requests which cannot be responded to with speech, such as AudioPlayer, PlaybackController, SessionEndedRequest and
+// System.ExceptionEncountered requests, receive an empty Response.
+func ApologyErrorHandler(apology string) ErrorHandler {
+	return func(ctx context.Context, request *Request, err error) *Response {
+		if request != nil && !acceptsSpeech(request.Request) {
+			return &Response{Response: &ResponseData{}}
+		}
+		return &Response{
+			Response: &ResponseData{
+				OutputSpeech:     PlainSpeech(apology),
+				ShouldEndSession: Bool(true),
+			},
+		}
+	}
+}
+
+// acceptsSpeech reports whether the response to request may include output speech
+func acceptsSpeech(request RequestType) bool {
+	switch request.(type) {
+	case *SessionEndedRequest, *SystemExceptionEncounteredRequest,
+		*AudioPlayerPlaybackStartedRequest, *AudioPlayerPlaybackFinishedRequest, *AudioPlayerPlaybackStoppedRequest,
+		*AudioPlayerPlaybackNearlyFinishedRequest, *AudioPlayerPlaybackFailedRequest:
+		return false
+	}
+	return !IsPlaybackControllerRequest(request)
+}
+
+// NoHandlerError is returned by a Skill when there is no handler registered for a request and no fallback handler
+type NoHandlerError struct {
+	// RequestType is the type of the unhandled request
+	RequestType RequestTypeName
+
+	// Intent is the name of the unhandled intent for an IntentRequest
+	Intent string
+}
+
+// Error implements the error interface for the NoHandlerError type
+func (err *NoHandlerError) Error() string {
+	if err.Intent != "" {
+		return fmt.Sprintf("no handler for %s %s", err.RequestType, err.Intent)
+	}
+	return fmt.Sprintf("no handler for %s", err.RequestType)
+}
+
+// Skill is a http.Handler which decodes Alexa requests and dispatches them to the handler registered for the request,
+// writing the returned Response as JSON.
+//
+// An IntentRequest is dispatched to the handler registered for its intent name with HandleIntent. Built in intents use
+// the names of the built in request types, e.g. string(HelpRequestType) for AMAZON.HelpIntent. If no handler is
+// registered for the intent, or the request is of another type, the request is dispatched to the handler registered for
+// the request type with HandleRequest. Requests matching neither are dispatched to the fallback handler.
+//
+// Request interceptors are called in the order they were added before the handler, and response interceptors are
+// called in the order they were added with the Response, including a Response returned by a request interceptor. The
+// Response to a PlaybackController request is checked with ValidatePlaybackControllerResponse. If an
+// error handler has been set with HandleError, errors from interceptors and handlers are turned into a Response by the
+// error handler rather than resulting in a http.StatusInternalServerError.
+//
+// When the Skill is wrapped by the Verifier Middleware, the request decoded by the Verifier is used rather than decoding
+// the body again.
+type Skill struct {
+	requestHandlers      map[RequestTypeName]Handler
+	intentHandlers       map[string]Handler
+	fallback             Handler
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
+	errorHandler         ErrorHandler
+	validateResponses    bool
+	truncateSpeech       bool
+	logger               *log.Logger
+}
+
+// NewSkill returns a Skill with no registered handlers, which does not log
+func NewSkill() *Skill {
+	return &Skill{
+		requestHandlers: map[RequestTypeName]Handler{},
+		intentHandlers:  map[string]Handler{},
+		logger:          log.New(ioutil.Discard, "", 0),
+	}
+}
+
+// HandleRequest registers the handler for requests of type requestType
+func (skill *Skill) HandleRequest(requestType RequestTypeName, handler Handler) {
+	skill.requestHandlers[requestType] = handler
+}
+
+// HandleRequestFunc registers the handler function for requests of type requestType
+func (skill *Skill) HandleRequestFunc(requestType RequestTypeName, handler func(context.Context, *Request) (*Response, error)) {
+	skill.HandleRequest(requestType, HandlerFunc(handler))
+}
+
+// HandleIntent registers the handler for IntentRequests for the intent called name
+func (skill *Skill) HandleIntent(name string, handler Handler) {
+	skill.intentHandlers[name] = handler
+}
+
+// HandleIntentFunc registers the handler function for IntentRequests for the intent called name
+func (skill *Skill) HandleIntentFunc(name string, handler func(context.Context, *Request) (*Response, error)) {
+	skill.HandleIntent(name, HandlerFunc(handler))
+}
+
+// HandleFallback registers the handler for requests which do not match any other registered handler
+func (skill *Skill) HandleFallback(handler Handler) {
+	skill.fallback = handler
+}
+
+// HandleFallbackFunc registers the handler function for requests which do not match any other registered handler
+func (skill *Skill) HandleFallbackFunc(handler func(context.Context, *Request) (*Response, error)) {
+	skill.HandleFallback(HandlerFunc(handler))
+}
+
+// AddRequestInterceptor appends interceptors to the request interceptors called before a request is dispatched
+func (skill *Skill) AddRequestInterceptor(interceptors ...RequestInterceptor) {
+	skill.requestInterceptors = append(skill.requestInterceptors, interceptors...)
+}
+
+// AddResponseInterceptor appends interceptors to the response interceptors called with the Response for a request
+func (skill *Skill) AddResponseInterceptor(interceptors ...ResponseInterceptor) {
+	skill.responseInterceptors = append(skill.responseInterceptors, interceptors...)
+}
+
+// HandleError sets the error handler which turns errors from interceptors and handlers into a Response
+func (skill *Skill) HandleError(handler ErrorHandler) {
+	skill.errorHandler = handler
+}
+
+// Handler returns the handler that request will be dispatched to. A *NoHandlerError is returned if there is no
+// matching handler and no fallback handler.
+func (skill *Skill) Handler(request *Request) (Handler, error) {
+	if request.Request == nil {
+		return nil, fmt.Errorf("request has no request details")
+	}
+
+	requestType := request.Request.GetType()
+	noHandler := &NoHandlerError{RequestType: requestType}
+
+	if intentRequest, ok := request.Request.(*IntentRequest); ok && intentRequest.Intent != nil {
+		if handler, found := skill.intentHandlers[intentRequest.Intent.Name]; found {
+			return handler, nil
+		}
+		noHandler.Intent = intentRequest.Intent.Name
+	}
+
+	if handler, found := skill.requestHandlers[requestType]; found {
+		return handler, nil
+	}
+
+	if skill.fallback != nil {
+		return skill.fallback, nil
+	}
+	return nil, noHandler
+}
+
+// ValidateResponses sets the Skill to check every Response against the limits Alexa places on a response before it is
+// returned, see Response Validate. A Response which exceeds a limit is treated as an error.
+func (skill *Skill) ValidateResponses() {
+	skill.validateResponses = true
+}
+
+// SetLogger sets the logger that errors which result in a http.StatusInternalServerError are written to. The error is
+// not included in the response, so that internal details are not sent to the caller.
+func (skill *Skill) SetLogger(logger *log.Logger) {
+	skill.logger = logger
+}
+
+// TruncateSpeech sets the Skill to shorten speech which is too long at a sentence boundary, rather than failing, see
+// Response TruncateSpeech. It also enables ValidateResponses, so that other limits are still enforced.
+func (skill *Skill) TruncateSpeech() {
+	skill.truncateSpeech = true
+	skill.validateResponses = true
+}
+
+// ServeAlexa implements the Handler interface for the Skill type, running the interceptors around dispatching request
+// to the matching handler. If an error handler has been set, any error is passed to it and its Response returned. The
+// error handler is passed the context returned by the request interceptors.
+func (skill *Skill) ServeAlexa(ctx context.Context, request *Request) (*Response, error) {
+	ctx, response, err := skill.serve(ctx, request)
+	if err != nil && skill.errorHandler != nil {
+		return skill.errorHandler(ctx, request, err), nil
+	}
+	return response, err
+}
+
+// serve runs the request interceptors, handler and response interceptors for request, then validates the response. The
+// context returned by the last successful request interceptor is returned with the response or error.
+func (skill *Skill) serve(ctx context.Context, request *Request) (context.Context, *Response, error) {
+	var response *Response
+	for _, interceptor := range skill.requestInterceptors {
+		interceptorCtx, interceptorResponse, err := interceptor(ctx, request)
+		if err != nil {
+			return ctx, nil, err
+		}
+		ctx, response = interceptorCtx, interceptorResponse
+		if response != nil {
+			break
+		}
+	}
+
+	if response == nil {
+		handler, err := skill.Handler(request)
+		if err != nil {
+			return ctx, nil, err
+		}
+		if response, err = handler.ServeAlexa(ctx, request); err != nil {
+			return ctx, nil, err
+		}
+	}
+
+	if response == nil {
+		response = &Response{Response: &ResponseData{}}
+	}
+
+	var err error
+
+	for _, interceptor := range skill.responseInterceptors {
+		if response, err = interceptor(ctx, request, response); err != nil {
+			return ctx, nil, err
+		}
+	}
+
+	if IsPlaybackControllerRequest(request.Request) {
+		if err := ValidatePlaybackControllerResponse(response); err != nil {
+			return ctx, nil, err
+		}
+	}
+
+	var device *Device
+	if request.Context != nil && request.Context.System != nil {
+		device = request.Context.System.Device
+	}
+	if err := ValidateVideoAppResponse(response, device); err != nil {
+		return ctx, nil, err
+	}
+
+	if skill.truncateSpeech {
+		response.TruncateSpeech()
+	}
+	if skill.validateResponses {
+		if err := response.Validate(); err != nil {
+			return ctx, nil, err
+		}
+	}
+	return ctx, response, nil
+}
+
+// ServeHTTP implements the http.Handler interface for the Skill type
+func (skill *Skill) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	request, ok := RequestFromContext(req.Context())
+	if !ok {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, "Cannot read body", http.StatusBadRequest)
+			return
+		}
+
+		request = &Request{}
+		if err := json.Unmarshal(body, request); err != nil {
+			http.Error(w, "Invalid Request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := skill.ServeAlexa(req.Context(), request)
+	if err != nil {
+		skill.internalServerError(w, err)
+		return
+	}
+	if response == nil {
+		response = &Response{Response: &ResponseData{}}
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		skill.internalServerError(w, err)
+		return
+	}
+
+	w.Header().Set(contentHeader, jsonContentType)
+	w.Write(data)
+}
+
+// internalServerError logs err and writes a http.StatusInternalServerError to w without the details of err
+func (skill *Skill) internalServerError(w http.ResponseWriter, err error) {
+	skill.logger.Printf("alexa: request failed: %s", err)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
@@ -0,0 +1,463 @@
+package alexa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testIntentRequestJSON returns the JSON for an IntentRequest for the intent called name
+func testIntentRequestJSON(name string) []byte {
+	return []byte(fmt.Sprintf(`{"version":"1.0","request":{"type":"IntentRequest","requestId":"1","intent":{"name":"%s"}}}`, name))
+}
+
+// testSpeechHandler returns a HandlerFunc which responds with speech
+func testSpeechHandler(speech string) HandlerFunc {
+	return func(ctx context.Context, request *Request) (*Response, error) {
+		return &Response{Response: &ResponseData{OutputSpeech: PlainSpeech(speech)}}, nil
+	}
+}
+
+// testServeSkill makes a HTTP request with body to skill and returns the recorded response
+func testServeSkill(skill http.Handler, body []byte) *httptest.ResponseRecorder {
+	response := httptest.NewRecorder()
+	skill.ServeHTTP(response, httptest.NewRequest(http.MethodPost, `/`, bytes.NewReader(body)))
+	return response
+}
+
+// testResponseSpeech decodes the plain text output speech from a recorded response
+func testResponseSpeech(response *httptest.ResponseRecorder) string {
+	decoded := &struct {
+		Response struct {
+			OutputSpeech struct {
+				Text string `json:"text"`
+			} `json:"outputSpeech"`
+		} `json:"response"`
+	}{}
+	if err := json.Unmarshal(response.Body.Bytes(), decoded); err != nil {
+		panic(err)
+	}
+	return decoded.Response.OutputSpeech.Text
+}
+
+func TestSkill_ServeHTTP(t *testing.T) {
+	Convey(`Given I have a Skill with request type and intent handlers`, t, func() {
+		skill := NewSkill()
+		skill.HandleRequest(LaunchRequestType, testSpeechHandler(`launch`))
+		skill.HandleRequest(IntentRequestType, testSpeechHandler(`intent`))
+		skill.HandleIntent(`RollDice`, testSpeechHandler(`roll`))
+		skill.HandleIntent(string(HelpRequestType), testSpeechHandler(`help`))
+
+		Convey(`When I make a LaunchRequest`, func() {
+			response := testServeSkill(skill, launchRequestJSON)
+
+			Convey(`Then the status code will be StatusOK`, func() {
+				So(response.Code, ShouldEqual, http.StatusOK)
+			})
+
+			Convey(`Then the response will have the JSON content type`, func() {
+				So(response.Header().Get(contentHeader), ShouldEqual, jsonContentType)
+			})
+
+			Convey(`Then the launch handler will have responded`, func() {
+				So(testResponseSpeech(response), ShouldEqual, `launch`)
+			})
+		})
+
+		Convey(`When I make an IntentRequest for a registered intent`, func() {
+			response := testServeSkill(skill, testIntentRequestJSON(`RollDice`))
+
+			Convey(`Then the intent handler will have responded`, func() {
+				So(testResponseSpeech(response), ShouldEqual, `roll`)
+			})
+		})
+
+		Convey(`When I make an IntentRequest for a built in intent`, func() {
+			response := testServeSkill(skill, testIntentRequestJSON(string(HelpRequestType)))
+
+			Convey(`Then the built in intent handler will have responded`, func() {
+				So(testResponseSpeech(response), ShouldEqual, `help`)
+			})
+		})
+
+		Convey(`When I make an IntentRequest for an unregistered intent`, func() {
+			response := testServeSkill(skill, testIntentRequestJSON(`Unknown`))
+
+			Convey(`Then the request type handler will have responded`, func() {
+				So(testResponseSpeech(response), ShouldEqual, `intent`)
+			})
+		})
+
+		Convey(`When I make a SessionEndedRequest`, func() {
+			response := testServeSkill(skill, sessionEndedRequestJSON)
+
+			Convey(`Then the status code will be StatusInternalServerError`, func() {
+				So(response.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey(`And I register a fallback handler`, func() {
+			skill.HandleFallback(testSpeechHandler(`fallback`))
+
+			Convey(`When I make a SessionEndedRequest`, func() {
+				response := testServeSkill(skill, sessionEndedRequestJSON)
+
+				Convey(`Then the fallback handler will have responded`, func() {
+					So(testResponseSpeech(response), ShouldEqual, `fallback`)
+				})
+			})
+
+			Convey(`When I make a request of a type unknown to the package`, func() {
+				response := testServeSkill(skill, []byte(`{"version":"1.0","request":{"type":"CanFulfillIntentRequest","requestId":"1"}}`))
+
+				Convey(`Then the fallback handler will have responded`, func() {
+					So(testResponseSpeech(response), ShouldEqual, `fallback`)
+				})
+			})
+		})
+
+		Convey(`And I register a handler for a request type unknown to the package`, func() {
+			skill.HandleRequest(`CanFulfillIntentRequest`, testSpeechHandler(`can fulfill`))
+
+			Convey(`When I make a request of that type`, func() {
+				response := testServeSkill(skill, []byte(`{"version":"1.0","request":{"type":"CanFulfillIntentRequest","requestId":"1"}}`))
+
+				Convey(`Then the request type handler will have responded`, func() {
+					So(testResponseSpeech(response), ShouldEqual, `can fulfill`)
+				})
+			})
+		})
+
+		Convey(`When I make a request which is not valid JSON`, func() {
+			response := testServeSkill(skill, []byte(`not json`))
+
+			Convey(`Then the status code will be StatusBadRequest`, func() {
+				So(response.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey(`When I make a request with the decoded request on the context`, func() {
+			request := &Request{Request: &IntentRequest{BaseRequestType: &BaseRequestType{}, Intent: &Intent{Name: `RollDice`}}}
+
+			response := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, `/`, nil)
+			skill.ServeHTTP(response, req.WithContext(NewContext(req.Context(), request, nil)))
+
+			Convey(`Then the request on the context will have been dispatched`, func() {
+				So(testResponseSpeech(response), ShouldEqual, `roll`)
+			})
+		})
+	})
+
+	Convey(`Given I have a Skill with a handler which returns no response`, t, func() {
+		skill := NewSkill()
+		skill.HandleRequestFunc(SessionEndedRequestType, func(context.Context, *Request) (*Response, error) {
+			return nil, nil
+		})
+
+		Convey(`When I make a SessionEndedRequest`, func() {
+			response := testServeSkill(skill, sessionEndedRequestJSON)
+
+			Convey(`Then the status code will be StatusOK`, func() {
+				So(response.Code, ShouldEqual, http.StatusOK)
+			})
+
+			Convey(`Then an empty response will be written`, func() {
+				So(response.Body.String(), ShouldContainSubstring, `"version":"1.0"`)
+			})
+		})
+	})
+}
+
+func TestSkill_Handler(t *testing.T) {
+	Convey(`Given I have a Skill with no handlers`, t, func() {
+		skill := NewSkill()
+
+		Convey(`When I find the handler for an IntentRequest`, func() {
+			request := &Request{}
+			if err := json.Unmarshal(testIntentRequestJSON(`RollDice`), request); err != nil {
+				panic(err)
+			}
+			_, err := skill.Handler(request)
+
+			Convey(`Then the error will be a NoHandlerError for the intent`, func() {
+				So(err, ShouldResemble, &NoHandlerError{RequestType: IntentRequestType, Intent: `RollDice`})
+			})
+		})
+	})
+}
+
+// testContextKey is the type of the context keys used in the Skill tests
+type testContextKey string
+
+func TestSkill_Interceptors(t *testing.T) {
+	Convey(`Given I have a Skill with request and response interceptors`, t, func() {
+		var calls []string
+
+		skill := NewSkill()
+		skill.HandleRequestFunc(LaunchRequestType, func(ctx context.Context, request *Request) (*Response, error) {
+			calls = append(calls, `handler`)
+			return testSpeechHandler(fmt.Sprint(ctx.Value(testContextKey(`user`))))(ctx, request)
+		})
+		skill.AddRequestInterceptor(
+			func(ctx context.Context, request *Request) (context.Context, *Response, error) {
+				calls = append(calls, `request 1`)
+				return context.WithValue(ctx, testContextKey(`user`), `loaded user`), nil, nil
+			},
+			func(ctx context.Context, request *Request) (context.Context, *Response, error) {
+				calls = append(calls, `request 2`)
+				return ctx, nil, nil
+			},
+		)
+		skill.AddResponseInterceptor(func(ctx context.Context, request *Request, response *Response) (*Response, error) {
+			calls = append(calls, `response`)
+			response.SessionAttributes = map[string]interface{}{`saved`: true}
+			return response, nil
+		})
+
+		Convey(`When I make a LaunchRequest`, func() {
+			response := testServeSkill(skill, launchRequestJSON)
+
+			Convey(`Then the interceptors and handler will have been called in order`, func() {
+				So(calls, ShouldResemble, []string{`request 1`, `request 2`, `handler`, `response`})
+			})
+
+			Convey(`Then the handler will have received the context from the request interceptors`, func() {
+				So(testResponseSpeech(response), ShouldEqual, `loaded user`)
+			})
+
+			Convey(`Then the response will have been modified by the response interceptor`, func() {
+				So(response.Body.String(), ShouldContainSubstring, `"sessionAttributes":{"saved":true}`)
+			})
+		})
+
+		Convey(`And I add a request interceptor which short-circuits the request`, func() {
+			skill.requestInterceptors = append([]RequestInterceptor{
+				func(ctx context.Context, request *Request) (context.Context, *Response, error) {
+					calls = append(calls, `short-circuit`)
+					return ctx, testSpeechHandlerResponse(`short-circuited`), nil
+				},
+			}, skill.requestInterceptors...)
+
+			Convey(`When I make a LaunchRequest`, func() {
+				response := testServeSkill(skill, launchRequestJSON)
+
+				Convey(`Then the remaining request interceptors and handler will be skipped`, func() {
+					So(calls, ShouldResemble, []string{`short-circuit`, `response`})
+				})
+
+				Convey(`Then the short-circuit response will be written`, func() {
+					So(testResponseSpeech(response), ShouldEqual, `short-circuited`)
+				})
+			})
+		})
+	})
+
+	Convey(`Given I have a Skill with a response interceptor and a handler which returns no response`, t, func() {
+		skill := NewSkill()
+		skill.HandleRequestFunc(SessionEndedRequestType, func(context.Context, *Request) (*Response, error) {
+			return nil, nil
+		})
+
+		var intercepted *Response
+		skill.AddResponseInterceptor(func(ctx context.Context, request *Request, response *Response) (*Response, error) {
+			intercepted = response
+			response.SessionAttributes = map[string]interface{}{`saved`: true}
+			return response, nil
+		})
+
+		Convey(`When I make a SessionEndedRequest`, func() {
+			response := testServeSkill(skill, sessionEndedRequestJSON)
+
+			Convey(`Then the status code will be StatusOK`, func() {
+				So(response.Code, ShouldEqual, http.StatusOK)
+			})
+
+			Convey(`Then the response interceptor will have received an empty response`, func() {
+				So(intercepted, ShouldNotBeNil)
+				So(intercepted.Response, ShouldNotBeNil)
+			})
+
+			Convey(`Then the response will have been modified by the response interceptor`, func() {
+				So(response.Body.String(), ShouldContainSubstring, `"sessionAttributes":{"saved":true}`)
+			})
+		})
+	})
+}
+
+// testSpeechHandlerResponse returns a Response with speech
+func testSpeechHandlerResponse(speech string) *Response {
+	response, _ := testSpeechHandler(speech)(context.Background(), nil)
+	return response
+}
+
+func TestSkill_HandleError(t *testing.T) {
+	Convey(`Given I have a Skill with a handler which returns an error`, t, func() {
+		skill := NewSkill()
+		skill.HandleRequestFunc(LaunchRequestType, func(context.Context, *Request) (*Response, error) {
+			return nil, fmt.Errorf(`handler failed`)
+		})
+
+		Convey(`When I make a LaunchRequest`, func() {
+			response := testServeSkill(skill, launchRequestJSON)
+
+			Convey(`Then the status code will be StatusInternalServerError`, func() {
+				So(response.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+
+			Convey(`Then the handler error will not be sent to the caller`, func() {
+				So(strings.TrimSpace(response.Body.String()), ShouldEqual, http.StatusText(http.StatusInternalServerError))
+			})
+		})
+
+		Convey(`And I set an apology error handler`, func() {
+			skill.HandleError(ApologyErrorHandler(`Sorry, something went wrong`))
+
+			Convey(`When I make a LaunchRequest`, func() {
+				response := testServeSkill(skill, launchRequestJSON)
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the apology will be spoken`, func() {
+					So(testResponseSpeech(response), ShouldEqual, `Sorry, something went wrong`)
+				})
+			})
+
+			Convey(`When I make a request with no matching handler`, func() {
+				response := testServeSkill(skill, testIntentRequestJSON(`Unknown`))
+
+				Convey(`Then the apology will be spoken`, func() {
+					So(testResponseSpeech(response), ShouldEqual, `Sorry, something went wrong`)
+				})
+			})
+
+			Convey(`When I make a SessionEndedRequest with no matching handler`, func() {
+				response := testServeSkill(skill, sessionEndedRequestJSON)
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the apology will not be spoken`, func() {
+					So(response.Body.String(), ShouldNotContainSubstring, `outputSpeech`)
+				})
+			})
+
+			Convey(`When I make an AudioPlayer request with no matching handler`, func() {
+				response := testServeSkill(skill, audioPlayerRequestJSON(AudioPlayerPlaybackStartedRequestType))
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the apology will not be spoken`, func() {
+					So(response.Body.String(), ShouldNotContainSubstring, `outputSpeech`)
+				})
+			})
+		})
+
+		Convey(`And I set an error handler and a request interceptor which returns an error`, func() {
+			var handledErr error
+			skill.HandleError(func(ctx context.Context, request *Request, err error) *Response {
+				handledErr = err
+				return testSpeechHandlerResponse(`handled`)
+			})
+			skill.AddRequestInterceptor(func(ctx context.Context, request *Request) (context.Context, *Response, error) {
+				return ctx, nil, fmt.Errorf(`interceptor failed`)
+			})
+
+			Convey(`When I make a LaunchRequest`, func() {
+				testServeSkill(skill, launchRequestJSON)
+
+				Convey(`Then the error handler will receive the interceptor error`, func() {
+					So(handledErr.Error(), ShouldEqual, `interceptor failed`)
+				})
+			})
+		})
+
+		Convey(`And I set an error handler and a request interceptor which adds to the context`, func() {
+			var handledUser interface{}
+			skill.HandleError(func(ctx context.Context, request *Request, err error) *Response {
+				handledUser = ctx.Value(testContextKey(`user`))
+				return testSpeechHandlerResponse(`handled`)
+			})
+			skill.AddRequestInterceptor(func(ctx context.Context, request *Request) (context.Context, *Response, error) {
+				return context.WithValue(ctx, testContextKey(`user`), `loaded user`), nil, nil
+			})
+
+			Convey(`When I make a LaunchRequest`, func() {
+				testServeSkill(skill, launchRequestJSON)
+
+				Convey(`Then the error handler will receive the context from the request interceptor`, func() {
+					So(handledUser, ShouldEqual, `loaded user`)
+				})
+			})
+		})
+	})
+}
+
+func TestSkill_ValidateResponses(t *testing.T) {
+	Convey(`Given I have a Skill whose handler responds with speech which is too long`, t, func() {
+		skill := NewSkill()
+		skill.HandleRequest(LaunchRequestType, testSpeechHandler(strings.Repeat(`Too long. `, MaxSpeechLength/10+1)))
+
+		Convey(`When I make a LaunchRequest without validation`, func() {
+			response := testServeSkill(skill, launchRequestJSON)
+
+			Convey(`Then the status code will be StatusOK`, func() {
+				So(response.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey(`And I enable response validation`, func() {
+			skill.ValidateResponses()
+
+			logged := &bytes.Buffer{}
+			skill.SetLogger(log.New(logged, "", 0))
+
+			Convey(`When I make a LaunchRequest`, func() {
+				response := testServeSkill(skill, launchRequestJSON)
+
+				Convey(`Then the status code will be StatusInternalServerError`, func() {
+					So(response.Code, ShouldEqual, http.StatusInternalServerError)
+				})
+
+				Convey(`Then the invalid field will be logged`, func() {
+					So(logged.String(), ShouldContainSubstring, `response.outputSpeech`)
+				})
+
+				Convey(`Then the invalid field will not be sent to the caller`, func() {
+					So(response.Body.String(), ShouldNotContainSubstring, `response.outputSpeech`)
+				})
+			})
+		})
+
+		Convey(`And I enable speech truncation`, func() {
+			skill.TruncateSpeech()
+
+			Convey(`When I make a LaunchRequest`, func() {
+				response := testServeSkill(skill, launchRequestJSON)
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the speech will be truncated at a sentence`, func() {
+					speech := testResponseSpeech(response)
+					So(len(speech), ShouldBeLessThanOrEqualTo, MaxSpeechLength)
+					So(speech, ShouldEndWith, `Too long.`)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,149 @@
+package alexa
+
+import (
+	"fmt"
+)
+
+// SlotValueType indicates whether a SlotValue holds a single value or a list of values
+type SlotValueType string
+
+const (
+	// SlotValueTypeSimple indicates the SlotValue holds a single value
+	SlotValueTypeSimple SlotValueType = "Simple"
+	// SlotValueTypeList indicates the SlotValue holds the list of values spoken for a multi-value slot
+	SlotValueTypeList SlotValueType = "List"
+)
+
+// SlotValue is the value, or for multi-value slots the list of values, the user spoke for a slot
+type SlotValue struct {
+	// Type indicates whether the SlotValue holds a single value or a list of values
+	Type SlotValueType `json:"type"`
+
+	// Value represents the value the user spoke. Only set for SlotValueTypeSimple.
+	Value string `json:"value,omitempty"`
+
+	// Resolutions represents the results of resolving Value. Only set for SlotValueTypeSimple.
+	Resolutions *Resolution `json:"resolutions,omitempty"`
+
+	// Values holds each of the values the user spoke, in the order they were spoken. Only set for SlotValueTypeList.
+	Values []*SlotValue `json:"values,omitempty"`
+}
+
+// ResolvedValue returns the first value matched by any authority for the spoken value. nil is returned without an
+// error if no authority matched the value. A *ResolutionError is returned if no authority matched the value and an
+// authority failed with ER_ERROR_TIMEOUT or ER_ERROR_EXCEPTION.
+func (value *SlotValue) ResolvedValue() (*ResolvedValue, error) {
+	if value == nil {
+		return nil, nil
+	}
+	return value.Resolutions.resolve()
+}
+
+// ResolutionError is returned when a slot value could not be resolved because an authority failed
+type ResolutionError struct {
+	// Slot is the name of the slot which could not be resolved
+	Slot string
+
+	// Authority is the name of the authority which failed
+	Authority string
+
+	// Code is either ResolutionStatusCodeTimeout or ResolutionStatusCodeException
+	Code ResolutionStatusCode
+}
+
+// Error implements the error interface for the ResolutionError type
+func (err *ResolutionError) Error() string {
+	return fmt.Sprintf("slot %q could not be resolved by %s: %s", err.Slot, err.Authority, err.Code)
+}
+
+// Slot returns the slot called name, or nil if the intent has no such slot
+func (intent *Intent) Slot(name string) *Slot {
+	if intent == nil {
+		return nil
+	}
+	return intent.Slots[name]
+}
+
+// Values returns each of the values the user spoke for the slot. A single value is returned for slots which do not
+// accept multiple values, and none if the user did not fill the slot.
+func (slot *Slot) Values() []*SlotValue {
+	switch {
+	case slot == nil:
+		return nil
+	case slot.SlotValue == nil:
+		if slot.Value == "" {
+			return nil
+		}
+		return []*SlotValue{{Type: SlotValueTypeSimple, Value: slot.Value, Resolutions: slot.Resolutions}}
+	case slot.SlotValue.Type == SlotValueTypeList:
+		return slot.SlotValue.Values
+	default:
+		return []*SlotValue{slot.SlotValue}
+	}
+}
+
+// ResolvedValue returns the first value matched by any authority for the slot, or for the first value spoken for a
+// multi-value slot. nil is returned without an error if the slot is empty or no authority matched the value. A
+// *ResolutionError is returned if no authority matched the value and an authority failed.
+func (slot *Slot) ResolvedValue() (*ResolvedValue, error) {
+	values := slot.Values()
+	if len(values) == 0 {
+		return nil, nil
+	}
+	resolved, err := values[0].ResolvedValue()
+	return resolved, slot.resolutionError(err)
+}
+
+// IsMatched reports whether an authority matched the slot value
+func (slot *Slot) IsMatched() bool {
+	resolved, _ := slot.ResolvedValue()
+	return resolved != nil
+}
+
+// AllResolved returns the resolved value of each value spoken for the slot, in the order they were spoken. Values
+// which no authority matched are skipped. A *ResolutionError is returned for the first value which could not be
+// resolved because an authority failed.
+func (slot *Slot) AllResolved() ([]*ResolvedValue, error) {
+	var resolved []*ResolvedValue
+	for _, value := range slot.Values() {
+		match, err := value.ResolvedValue()
+		if err != nil {
+			return nil, slot.resolutionError(err)
+		}
+		if match != nil {
+			resolved = append(resolved, match)
+		}
+	}
+	return resolved, nil
+}
+
+// resolutionError sets the slot name on a *ResolutionError returned by a SlotValue
+func (slot *Slot) resolutionError(err error) error {
+	if resolutionErr, ok := err.(*ResolutionError); ok {
+		resolutionErr.Slot = slot.Name
+	}
+	return err
+}
+
+// resolve returns the first value matched by any authority, or a *ResolutionError if none matched and an authority
+// failed
+func (resolution *Resolution) resolve() (*ResolvedValue, error) {
+	if resolution == nil {
+		return nil, nil
+	}
+
+	var err error
+	for _, authority := range resolution.Authorities {
+		if authority.IsMatch() {
+			return authority.Values[0].Value, nil
+		}
+		if authority == nil || authority.Status == nil || err != nil {
+			continue
+		}
+		switch authority.Status.Code {
+		case ResolutionStatusCodeTimeout, ResolutionStatusCodeException:
+			err = &ResolutionError{Authority: authority.Name, Code: authority.Status.Code}
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,145 @@
+package alexa
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSlotResolvedValue(t *testing.T) {
+	Convey(`Given an intent with a slot resolved by the dynamic authority`, t, func() {
+		slot := &Slot{}
+		if err := json.Unmarshal(resolvedSlotJSON, slot); err != nil {
+			panic(err)
+		}
+		intent := &Intent{Name: `OrderIntent`, Slots: map[string]*Slot{`dish`: slot}}
+
+		Convey(`When I get the slot by name`, func() {
+			found := intent.Slot(`dish`)
+
+			Convey(`Then it will be the slot`, func() {
+				So(found, ShouldEqual, slot)
+			})
+
+			Convey(`Then the slot will be matched`, func() {
+				So(found.IsMatched(), ShouldBeTrue)
+			})
+
+			Convey(`Then the resolved value will be the first match`, func() {
+				resolved, err := found.ResolvedValue()
+				So(err, ShouldBeNil)
+				So(resolved, ShouldResemble, &ResolvedValue{ID: `dish-1`, Name: `pad thai`})
+			})
+		})
+
+		Convey(`When I get a slot which does not exist`, func() {
+			missing := intent.Slot(`drink`)
+
+			Convey(`Then it will be nil`, func() {
+				So(missing, ShouldBeNil)
+			})
+
+			Convey(`Then it will not be matched and have no resolved value`, func() {
+				So(missing.IsMatched(), ShouldBeFalse)
+				resolved, err := missing.ResolvedValue()
+				So(resolved, ShouldBeNil)
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey(`Given a slot whose authority timed out`, t, func() {
+		slot := &Slot{
+			Name:  `dish`,
+			Value: `noodles`,
+			Resolutions: &Resolution{Authorities: []*ResolutionAuthority{{
+				Name:   `amzn1.er-authority.echo-sdk.amzn1.ask.skill.1234.Dish`,
+				Status: &ResolutionStatus{Code: ResolutionStatusCodeTimeout},
+			}}},
+		}
+
+		Convey(`When I get the resolved value`, func() {
+			resolved, err := slot.ResolvedValue()
+
+			Convey(`Then there will be no value`, func() {
+				So(resolved, ShouldBeNil)
+			})
+
+			Convey(`Then a ResolutionError will be returned`, func() {
+				So(err, ShouldHaveSameTypeAs, &ResolutionError{})
+				So(err, ShouldResemble, &ResolutionError{
+					Slot:      `dish`,
+					Authority: `amzn1.er-authority.echo-sdk.amzn1.ask.skill.1234.Dish`,
+					Code:      ResolutionStatusCodeTimeout,
+				})
+			})
+		})
+	})
+
+	Convey(`Given a multi-value slot`, t, func() {
+		slot := &Slot{}
+		if err := json.Unmarshal(multiValueSlotJSON, slot); err != nil {
+			panic(err)
+		}
+
+		Convey(`Then each spoken value will be returned`, func() {
+			values := slot.Values()
+			So(values, ShouldHaveLength, 3)
+			So(values[0].Value, ShouldEqual, `pad thai`)
+			So(values[2].Value, ShouldEqual, `spring rolls`)
+		})
+
+		Convey(`Then the resolved value will be the first spoken value`, func() {
+			resolved, err := slot.ResolvedValue()
+			So(err, ShouldBeNil)
+			So(resolved.ID, ShouldEqual, `dish-1`)
+		})
+
+		Convey(`Then all matched values will be resolved in the order spoken`, func() {
+			resolved, err := slot.AllResolved()
+			So(err, ShouldBeNil)
+			So(resolved, ShouldResemble, []*ResolvedValue{
+				{ID: `dish-1`, Name: `pad thai`},
+				{ID: `dish-3`, Name: `spring rolls`},
+			})
+		})
+	})
+}
+
+var multiValueSlotJSON = []byte(`
+{
+  "name": "dishes",
+  "confirmationStatus": "NONE",
+  "slotValue": {
+    "type": "List",
+    "values": [
+      {
+        "type": "Simple",
+        "value": "pad thai",
+        "resolutions": {"resolutionsPerAuthority": [{
+          "authority": "amzn1.er-authority.echo-sdk.amzn1.ask.skill.1234.Dish",
+          "status": {"code": "ER_SUCCESS_MATCH"},
+          "values": [{"value": {"name": "pad thai", "id": "dish-1"}}]
+        }]}
+      },
+      {
+        "type": "Simple",
+        "value": "fish cakes",
+        "resolutions": {"resolutionsPerAuthority": [{
+          "authority": "amzn1.er-authority.echo-sdk.amzn1.ask.skill.1234.Dish",
+          "status": {"code": "ER_SUCCESS_NO_MATCH"}
+        }]}
+      },
+      {
+        "type": "Simple",
+        "value": "spring rolls",
+        "resolutions": {"resolutionsPerAuthority": [{
+          "authority": "amzn1.er-authority.echo-sdk.amzn1.ask.skill.1234.Dish",
+          "status": {"code": "ER_SUCCESS_MATCH"},
+          "values": [{"value": {"name": "spring rolls", "id": "dish-3"}}]
+        }]}
+      }
+    ]
+  }
+}`)
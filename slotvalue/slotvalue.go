@@ -0,0 +1,330 @@
+// Package slotvalue parses the values of the built-in AMAZON.DATE, AMAZON.DURATION, AMAZON.TIME and AMAZON.NUMBER slot
+// types into Go types.
+//
+// Many slot values are relative, for example "this weekend" or "december twenty fifth", and are interpreted by a
+// Parser relative to the time and locale of the request they were received in.
+//
+// Further information can be found at
+// https://developer.amazon.com/docs/custom-skills/slot-type-reference.html
+package slotvalue
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tyndyll/alexa"
+)
+
+// Granularity indicates the span of time an AMAZON.DATE value refers to
+type Granularity string
+
+// Period is a named part of the day an AMAZON.TIME value may refer to instead of a clock time
+type Period string
+
+const (
+	// GranularityPresent indicates the value referred to the present moment, "PRESENT_REF"
+	GranularityPresent Granularity = "PRESENT"
+	// GranularityDay indicates the value referred to a single day, such as "2026-10-18"
+	GranularityDay Granularity = "DAY"
+	// GranularityWeek indicates the value referred to an ISO-8601 week, such as "2026-W42"
+	GranularityWeek Granularity = "WEEK"
+	// GranularityWeekend indicates the value referred to the weekend of an ISO-8601 week, such as "2026-W42-WE"
+	GranularityWeekend Granularity = "WEEKEND"
+	// GranularityMonth indicates the value referred to a month, such as "2026-10"
+	GranularityMonth Granularity = "MONTH"
+	// GranularitySeason indicates the value referred to a season, such as "2026-WI"
+	GranularitySeason Granularity = "SEASON"
+	// GranularityYear indicates the value referred to a year, such as "2026" or "2026-XX-XX"
+	GranularityYear Granularity = "YEAR"
+	// GranularityDecade indicates the value referred to a decade, such as "202X"
+	GranularityDecade Granularity = "DECADE"
+
+	// PeriodMorning is the morning, 06:00 until 12:00
+	PeriodMorning Period = "MO"
+	// PeriodAfternoon is the afternoon, 12:00 until 18:00
+	PeriodAfternoon Period = "AF"
+	// PeriodEvening is the evening, 18:00 until 21:00
+	PeriodEvening Period = "EV"
+	// PeriodNight is the night, 21:00 until 06:00 the following day
+	PeriodNight Period = "NI"
+)
+
+// southernHemisphereLocales are the locales whose seasons are those of the southern hemisphere
+var southernHemisphereLocales = map[string]bool{
+	"en-AU": true,
+	"en-NZ": true,
+	"pt-BR": true,
+}
+
+// decimalCommaLanguages are the languages which use a comma as the decimal separator
+var decimalCommaLanguages = map[string]bool{
+	"de": true,
+	"es": true,
+	"fr": true,
+	"it": true,
+	"pt": true,
+}
+
+var (
+	dayPattern      = regexp.MustCompile(`^(\d{4}|XXXX)-(\d{2})-(\d{2})$`)
+	weekPattern     = regexp.MustCompile(`^(\d{4})-W(\d{1,2})(-WE)?$`)
+	seasonPattern   = regexp.MustCompile(`^(\d{4})-(WI|SP|SU|FA)$`)
+	monthPattern    = regexp.MustCompile(`^(\d{4}|XXXX)-(\d{2}|XX)(?:-XX)?$`)
+	yearPattern     = regexp.MustCompile(`^(\d{4})$`)
+	decadePattern   = regexp.MustCompile(`^(\d{3})X$`)
+	timePattern     = regexp.MustCompile(`^(\d{2}):(\d{2})$`)
+	numberPattern   = regexp.MustCompile(`^-?\d+(?:\.\d+)?$`)
+	durationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?` +
+		`(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+)
+
+// ParseError is returned when a slot value cannot be parsed
+type ParseError struct {
+	// Type is the slot type the value was parsed as, such as AMAZON.DATE
+	Type string
+
+	// Value is the slot value which could not be parsed
+	Value string
+}
+
+// Error implements the error interface for the ParseError type
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("invalid %s value %q", err.Type, err.Value)
+}
+
+// DateRange is the span of time an AMAZON.DATE value refers to, from Start until, but not including, End. For
+// GranularityPresent Start and End are both the time of the request.
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+
+	// Granularity indicates the span of time the value referred to
+	Granularity Granularity
+}
+
+// Contains reports whether t falls within the DateRange
+func (dateRange DateRange) Contains(t time.Time) bool {
+	if dateRange.Granularity == GranularityPresent {
+		return t.Equal(dateRange.Start)
+	}
+	return !t.Before(dateRange.Start) && t.Before(dateRange.End)
+}
+
+// Time is an AMAZON.TIME value, either a clock time or a named Period
+type Time struct {
+	Hour   int
+	Minute int
+
+	// Period is set when the value was a named part of the day, in which case Hour and Minute are zero
+	Period Period
+}
+
+// On returns the span of time on day the Time refers to. For a clock time start and end are equal.
+func (t Time) On(day time.Time) (start, end time.Time) {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	switch t.Period {
+	case PeriodMorning:
+		return midnight.Add(6 * time.Hour), midnight.Add(12 * time.Hour)
+	case PeriodAfternoon:
+		return midnight.Add(12 * time.Hour), midnight.Add(18 * time.Hour)
+	case PeriodEvening:
+		return midnight.Add(18 * time.Hour), midnight.Add(21 * time.Hour)
+	case PeriodNight:
+		return midnight.Add(21 * time.Hour), midnight.AddDate(0, 0, 1).Add(6 * time.Hour)
+	}
+	start = time.Date(day.Year(), day.Month(), day.Day(), t.Hour, t.Minute, 0, 0, day.Location())
+	return start, start
+}
+
+// Parser parses slot values relative to the time and locale of a request
+type Parser struct {
+	// Now is the time relative values are resolved against. Dates are created in its location.
+	Now time.Time
+
+	// Locale is the locale of the request, such as en-GB, which determines the hemisphere of seasons and the decimal
+	// separator of numbers
+	Locale string
+}
+
+// NewParser returns a Parser resolving relative values against now and locale
+func NewParser(now time.Time, locale string) *Parser {
+	return &Parser{
+		Now:    now,
+		Locale: locale,
+	}
+}
+
+// NewRequestParser returns a Parser resolving relative values against the timestamp and locale of request. Alexa
+// timestamps are in UTC; use NewParser with the timestamp in the device's location if it is known.
+func NewRequestParser(request alexa.RequestType) *Parser {
+	return NewParser(request.GetTimestamp(), request.GetLocale())
+}
+
+// Date parses an AMAZON.DATE value. The following formats are supported:
+//   * PRESENT_REF, the present moment
+//   * 2026-10-18, a day. XXXX-10-18 refers to the next 18th October on or after Now
+//   * 2026-W42, an ISO-8601 week, and 2026-W42-WE, its weekend
+//   * 2026-10, a month. XXXX-10 refers to the next October which has not ended
+//   * 2026-WI, 2026-SP, 2026-SU and 2026-FA, the winter, spring, summer and autumn of a year. Winter in the northern
+//     hemisphere and summer in the southern hemisphere start in December and end in the following year
+//   * 2026, 2026-XX and 2026-XX-XX, a year
+//   * 202X, a decade
+func (parser *Parser) Date(value string) (DateRange, error) {
+	location := parser.Now.Location()
+	invalid := &ParseError{Type: "AMAZON.DATE", Value: value}
+
+	if value == "PRESENT_REF" {
+		return DateRange{Start: parser.Now, End: parser.Now, Granularity: GranularityPresent}, nil
+	}
+
+	if match := dayPattern.FindStringSubmatch(value); match != nil {
+		month, _ := strconv.Atoi(match[2])
+		day, _ := strconv.Atoi(match[3])
+		year := parser.Now.Year()
+		if match[1] != "XXXX" {
+			year, _ = strconv.Atoi(match[1])
+		}
+		start := time.Date(year, time.Month(month), day, 0, 0, 0, 0, location)
+		if start.Month() != time.Month(month) || start.Day() != day {
+			return DateRange{}, invalid
+		}
+		if match[1] == "XXXX" && start.AddDate(0, 0, 1).Before(parser.Now) {
+			start = start.AddDate(1, 0, 0)
+		}
+		return DateRange{Start: start, End: start.AddDate(0, 0, 1), Granularity: GranularityDay}, nil
+	}
+
+	if match := weekPattern.FindStringSubmatch(value); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		week, _ := strconv.Atoi(match[2])
+		start := isoWeekStart(year, week, location)
+		if isoYear, isoWeek := start.ISOWeek(); isoYear != year || isoWeek != week {
+			return DateRange{}, invalid
+		}
+		if match[3] != "" {
+			return DateRange{Start: start.AddDate(0, 0, 5), End: start.AddDate(0, 0, 7), Granularity: GranularityWeekend}, nil
+		}
+		return DateRange{Start: start, End: start.AddDate(0, 0, 7), Granularity: GranularityWeek}, nil
+	}
+
+	if match := seasonPattern.FindStringSubmatch(value); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		start := time.Date(year, parser.seasonStart(match[2]), 1, 0, 0, 0, 0, location)
+		return DateRange{Start: start, End: start.AddDate(0, 3, 0), Granularity: GranularitySeason}, nil
+	}
+
+	if match := monthPattern.FindStringSubmatch(value); match != nil {
+		if match[2] == "XX" {
+			if match[1] == "XXXX" {
+				return DateRange{}, invalid
+			}
+			return parser.Date(match[1])
+		}
+		month, _ := strconv.Atoi(match[2])
+		if month < 1 || month > 12 {
+			return DateRange{}, invalid
+		}
+		year := parser.Now.Year()
+		if match[1] != "XXXX" {
+			year, _ = strconv.Atoi(match[1])
+		}
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, location)
+		if match[1] == "XXXX" && start.AddDate(0, 1, 0).Before(parser.Now) {
+			start = start.AddDate(1, 0, 0)
+		}
+		return DateRange{Start: start, End: start.AddDate(0, 1, 0), Granularity: GranularityMonth}, nil
+	}
+
+	if match := yearPattern.FindStringSubmatch(value); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+		return DateRange{Start: start, End: start.AddDate(1, 0, 0), Granularity: GranularityYear}, nil
+	}
+
+	if match := decadePattern.FindStringSubmatch(value); match != nil {
+		decade, _ := strconv.Atoi(match[1])
+		start := time.Date(decade*10, time.January, 1, 0, 0, 0, 0, location)
+		return DateRange{Start: start, End: start.AddDate(10, 0, 0), Granularity: GranularityDecade}, nil
+	}
+
+	return DateRange{}, invalid
+}
+
+// seasonStart returns the month the season starts in, using meteorological seasons for the hemisphere of the locale
+func (parser *Parser) seasonStart(season string) time.Month {
+	months := map[string]time.Month{"SP": time.March, "SU": time.June, "FA": time.September, "WI": time.December}
+	if southernHemisphereLocales[parser.Locale] {
+		months = map[string]time.Month{"FA": time.March, "WI": time.June, "SP": time.September, "SU": time.December}
+	}
+	return months[season]
+}
+
+// isoWeekStart returns the Monday starting ISO-8601 week of year
+func isoWeekStart(year, week int, location *time.Location) time.Time {
+	// The 4th January is always in week 1
+	fourth := time.Date(year, time.January, 4, 0, 0, 0, 0, location)
+	offset := (int(fourth.Weekday()) + 6) % 7
+	return fourth.AddDate(0, 0, (week-1)*7-offset)
+}
+
+// Duration parses an ISO-8601 AMAZON.DURATION value, such as PT1H30M or P2W. Years, months, weeks and days are
+// measured from Now, so that P1M is the length of the month following Now.
+func (parser *Parser) Duration(value string) (time.Duration, error) {
+	match := durationPattern.FindStringSubmatch(value)
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, &ParseError{Type: "AMAZON.DURATION", Value: value}
+	}
+
+	var date [4]int
+	for i := range date {
+		date[i], _ = strconv.Atoi(match[i+1])
+	}
+	end := parser.Now.AddDate(date[0], date[1], date[2]*7+date[3])
+
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for i, unit := range units {
+		if match[i+5] == "" {
+			continue
+		}
+		amount, _ := strconv.ParseFloat(match[i+5], 64)
+		end = end.Add(time.Duration(amount * float64(unit)))
+	}
+	return end.Sub(parser.Now), nil
+}
+
+// Time parses an AMAZON.TIME value, either a clock time such as 14:30 or a named Period such as EV
+func (parser *Parser) Time(value string) (Time, error) {
+	switch period := Period(value); period {
+	case PeriodMorning, PeriodAfternoon, PeriodEvening, PeriodNight:
+		return Time{Period: period}, nil
+	}
+
+	match := timePattern.FindStringSubmatch(value)
+	if match == nil {
+		return Time{}, &ParseError{Type: "AMAZON.TIME", Value: value}
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	if hour > 23 || minute > 59 {
+		return Time{}, &ParseError{Type: "AMAZON.TIME", Value: value}
+	}
+	return Time{Hour: hour, Minute: minute}, nil
+}
+
+// Number parses an AMAZON.NUMBER value. Values which Alexa did not understand are sent as "?" and return a
+// *ParseError. A comma is accepted as the decimal separator for locales which use one.
+func (parser *Parser) Number(value string) (float64, error) {
+	normalised := value
+	language := strings.SplitN(parser.Locale, "-", 2)[0]
+	if decimalCommaLanguages[language] {
+		normalised = strings.Replace(normalised, ",", ".", 1)
+	}
+
+	if !numberPattern.MatchString(normalised) {
+		return 0, &ParseError{Type: "AMAZON.NUMBER", Value: value}
+	}
+	return strconv.ParseFloat(normalised, 64)
+}
@@ -0,0 +1,199 @@
+package slotvalue
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/tyndyll/alexa"
+)
+
+// testNow is Sunday 18th October 2026, in ISO week 42
+var testNow = time.Date(2026, time.October, 18, 15, 4, 5, 0, time.UTC)
+
+func testDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParserDate(t *testing.T) {
+	parser := NewParser(testNow, `en-GB`)
+
+	dates := map[string]DateRange{
+		`PRESENT_REF`: {Start: testNow, End: testNow, Granularity: GranularityPresent},
+		`2026-10-18`:  {Start: testDate(2026, time.October, 18), End: testDate(2026, time.October, 19), Granularity: GranularityDay},
+		`XXXX-12-25`:  {Start: testDate(2026, time.December, 25), End: testDate(2026, time.December, 26), Granularity: GranularityDay},
+		`XXXX-01-01`:  {Start: testDate(2027, time.January, 1), End: testDate(2027, time.January, 2), Granularity: GranularityDay},
+		`XXXX-10-18`:  {Start: testDate(2026, time.October, 18), End: testDate(2026, time.October, 19), Granularity: GranularityDay},
+		`2026-W42`:    {Start: testDate(2026, time.October, 12), End: testDate(2026, time.October, 19), Granularity: GranularityWeek},
+		`2026-W42-WE`: {Start: testDate(2026, time.October, 17), End: testDate(2026, time.October, 19), Granularity: GranularityWeekend},
+		`2026-W1`:     {Start: testDate(2025, time.December, 29), End: testDate(2026, time.January, 5), Granularity: GranularityWeek},
+		`2026-10`:     {Start: testDate(2026, time.October, 1), End: testDate(2026, time.November, 1), Granularity: GranularityMonth},
+		`XXXX-09`:     {Start: testDate(2027, time.September, 1), End: testDate(2027, time.October, 1), Granularity: GranularityMonth},
+		`2026-WI`:     {Start: testDate(2026, time.December, 1), End: testDate(2027, time.March, 1), Granularity: GranularitySeason},
+		`2026-SU`:     {Start: testDate(2026, time.June, 1), End: testDate(2026, time.September, 1), Granularity: GranularitySeason},
+		`2026`:        {Start: testDate(2026, time.January, 1), End: testDate(2027, time.January, 1), Granularity: GranularityYear},
+		`2026-XX-XX`:  {Start: testDate(2026, time.January, 1), End: testDate(2027, time.January, 1), Granularity: GranularityYear},
+		`202X`:        {Start: testDate(2020, time.January, 1), End: testDate(2030, time.January, 1), Granularity: GranularityDecade},
+	}
+
+	for value, expected := range dates {
+		Convey(`When I parse the AMAZON.DATE value `+value, t, func() {
+			dateRange, err := parser.Date(value)
+
+			Convey(`Then there will be no error`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the range will be correct`, func() {
+				So(dateRange, ShouldResemble, expected)
+			})
+		})
+	}
+
+	for _, value := range []string{`2026-02-30`, `2026-13`, `2026-W54`, `XXXX-XX`, `tomorrow`, ``} {
+		Convey(`When I parse the invalid AMAZON.DATE value `+value, t, func() {
+			_, err := parser.Date(value)
+
+			Convey(`Then a ParseError will be returned`, func() {
+				So(err, ShouldResemble, &ParseError{Type: `AMAZON.DATE`, Value: value})
+			})
+		})
+	}
+
+	Convey(`Given a parser for a southern hemisphere locale`, t, func() {
+		parser := NewParser(testNow, `en-AU`)
+
+		Convey(`When I parse a summer value`, func() {
+			dateRange, err := parser.Date(`2026-SU`)
+			So(err, ShouldBeNil)
+
+			Convey(`Then summer will start in December`, func() {
+				So(dateRange.Start, ShouldEqual, testDate(2026, time.December, 1))
+				So(dateRange.End, ShouldEqual, testDate(2027, time.March, 1))
+			})
+		})
+	})
+}
+
+func TestParserDuration(t *testing.T) {
+	parser := NewParser(testNow, `en-GB`)
+
+	durations := map[string]time.Duration{
+		`PT1H30M`: 90 * time.Minute,
+		`PT45S`:   45 * time.Second,
+		`PT1.5H`:  90 * time.Minute,
+		`P2W`:     14 * 24 * time.Hour,
+		`P1DT2H`:  26 * time.Hour,
+		`P1M`:     31 * 24 * time.Hour,
+	}
+
+	for value, expected := range durations {
+		Convey(`When I parse the AMAZON.DURATION value `+value, t, func() {
+			duration, err := parser.Duration(value)
+
+			Convey(`Then the duration will be correct`, func() {
+				So(err, ShouldBeNil)
+				So(duration, ShouldEqual, expected)
+			})
+		})
+	}
+
+	for _, value := range []string{`P`, `PT`, `P1DT`, `1H`, `PT1X`} {
+		Convey(`When I parse the invalid AMAZON.DURATION value `+value, t, func() {
+			_, err := parser.Duration(value)
+
+			Convey(`Then a ParseError will be returned`, func() {
+				So(err, ShouldResemble, &ParseError{Type: `AMAZON.DURATION`, Value: value})
+			})
+		})
+	}
+}
+
+func TestParserTime(t *testing.T) {
+	parser := NewParser(testNow, `en-GB`)
+
+	Convey(`When I parse a clock time`, t, func() {
+		value, err := parser.Time(`14:30`)
+
+		Convey(`Then the hour and minute will be set`, func() {
+			So(err, ShouldBeNil)
+			So(value, ShouldResemble, Time{Hour: 14, Minute: 30})
+		})
+
+		Convey(`Then it will refer to a single moment on a day`, func() {
+			start, end := value.On(testNow)
+			So(start, ShouldEqual, time.Date(2026, time.October, 18, 14, 30, 0, 0, time.UTC))
+			So(end, ShouldEqual, start)
+		})
+	})
+
+	Convey(`When I parse a named period`, t, func() {
+		value, err := parser.Time(`NI`)
+
+		Convey(`Then the period will be set`, func() {
+			So(err, ShouldBeNil)
+			So(value.Period, ShouldEqual, PeriodNight)
+		})
+
+		Convey(`Then it will span into the following day`, func() {
+			start, end := value.On(testNow)
+			So(start, ShouldEqual, time.Date(2026, time.October, 18, 21, 0, 0, 0, time.UTC))
+			So(end, ShouldEqual, time.Date(2026, time.October, 19, 6, 0, 0, 0, time.UTC))
+		})
+	})
+
+	for _, value := range []string{`24:00`, `12:60`, `2pm`, `XX`} {
+		Convey(`When I parse the invalid AMAZON.TIME value `+value, t, func() {
+			_, err := parser.Time(value)
+
+			Convey(`Then a ParseError will be returned`, func() {
+				So(err, ShouldResemble, &ParseError{Type: `AMAZON.TIME`, Value: value})
+			})
+		})
+	}
+}
+
+func TestParserNumber(t *testing.T) {
+	Convey(`When I parse an AMAZON.NUMBER value`, t, func() {
+		number, err := NewParser(testNow, `en-US`).Number(`-42`)
+
+		Convey(`Then the number will be correct`, func() {
+			So(err, ShouldBeNil)
+			So(number, ShouldEqual, -42)
+		})
+	})
+
+	Convey(`When I parse a decimal AMAZON.NUMBER value for a German locale`, t, func() {
+		number, err := NewParser(testNow, `de-DE`).Number(`2,5`)
+
+		Convey(`Then the comma will be the decimal separator`, func() {
+			So(err, ShouldBeNil)
+			So(number, ShouldEqual, 2.5)
+		})
+	})
+
+	for _, value := range []string{`?`, `NaN`, `0x10`, `2,5`} {
+		Convey(`When I parse the invalid AMAZON.NUMBER value `+value+` for an English locale`, t, func() {
+			_, err := NewParser(testNow, `en-US`).Number(value)
+
+			Convey(`Then a ParseError will be returned`, func() {
+				So(err, ShouldResemble, &ParseError{Type: `AMAZON.NUMBER`, Value: value})
+			})
+		})
+	}
+}
+
+func TestNewRequestParser(t *testing.T) {
+	Convey(`Given an intent request`, t, func() {
+		request := &alexa.IntentRequest{BaseRequestType: &alexa.BaseRequestType{Timestamp: testNow, Locale: `en-AU`}}
+
+		Convey(`When I create a parser for it`, func() {
+			parser := NewRequestParser(request)
+
+			Convey(`Then the timestamp and locale will be used`, func() {
+				So(parser.Now, ShouldEqual, testNow)
+				So(parser.Locale, ShouldEqual, `en-AU`)
+			})
+		})
+	})
+}
@@ -0,0 +1,386 @@
+package alexa
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// SSMLBreakStrength is the length of a pause, relative to the pauses Alexa inserts between words and sentences
+type SSMLBreakStrength string
+
+// SSMLDomain is a speaking style suited to a type of content
+type SSMLDomain string
+
+// SSMLEmotion is an emotion Alexa can express
+type SSMLEmotion string
+
+// SSMLEmphasisLevel is the amount of emphasis given to a phrase
+type SSMLEmphasisLevel string
+
+// SSMLIntensity is the intensity of an SSMLEmotion
+type SSMLIntensity string
+
+const (
+	// SSMLBreakNone removes any pause which would otherwise occur
+	SSMLBreakNone SSMLBreakStrength = "none"
+	// SSMLBreakXWeak is the same as SSMLBreakNone
+	SSMLBreakXWeak SSMLBreakStrength = "x-weak"
+	// SSMLBreakWeak is the same as SSMLBreakNone
+	SSMLBreakWeak SSMLBreakStrength = "weak"
+	// SSMLBreakMedium is the pause between words separated by a comma
+	SSMLBreakMedium SSMLBreakStrength = "medium"
+	// SSMLBreakStrong is the pause between sentences
+	SSMLBreakStrong SSMLBreakStrength = "strong"
+	// SSMLBreakXStrong is the pause between paragraphs
+	SSMLBreakXStrong SSMLBreakStrength = "x-strong"
+
+	// SSMLDomainConversational is a relaxed style suited to conversation
+	SSMLDomainConversational SSMLDomain = "conversational"
+	// SSMLDomainFun is an animated style suited to games and jokes
+	SSMLDomainFun SSMLDomain = "fun"
+	// SSMLDomainLongForm is a style suited to long passages, such as articles
+	SSMLDomainLongForm SSMLDomain = "long-form"
+	// SSMLDomainMusic is a style suited to talking about music
+	SSMLDomainMusic SSMLDomain = "music"
+	// SSMLDomainNews is a style suited to reading the news
+	SSMLDomainNews SSMLDomain = "news"
+
+	// SSMLEmotionDisappointed expresses disappointment
+	SSMLEmotionDisappointed SSMLEmotion = "disappointed"
+	// SSMLEmotionExcited expresses excitement
+	SSMLEmotionExcited SSMLEmotion = "excited"
+
+	// SSMLEmphasisModerate is the default emphasis, louder and slower than normal speech
+	SSMLEmphasisModerate SSMLEmphasisLevel = "moderate"
+	// SSMLEmphasisReduced is quieter and faster than normal speech
+	SSMLEmphasisReduced SSMLEmphasisLevel = "reduced"
+	// SSMLEmphasisStrong is louder and slower than SSMLEmphasisModerate
+	SSMLEmphasisStrong SSMLEmphasisLevel = "strong"
+
+	// SSMLIntensityHigh is a strongly expressed emotion
+	SSMLIntensityHigh SSMLIntensity = "high"
+	// SSMLIntensityLow is a mildly expressed emotion
+	SSMLIntensityLow SSMLIntensity = "low"
+	// SSMLIntensityMedium is a moderately expressed emotion
+	SSMLIntensityMedium SSMLIntensity = "medium"
+)
+
+// ssmlAttributes lists the elements Alexa supports and, for each element, its supported attributes. An attribute
+// mapped to a list only accepts the listed values.
+var ssmlAttributes = map[string]map[string][]string{
+	"speak":          {},
+	"p":              {},
+	"s":              {},
+	"audio":          {"src": nil},
+	"break":          {"strength": {"none", "x-weak", "weak", "medium", "strong", "x-strong"}, "time": nil},
+	"emphasis":       {"level": {"strong", "moderate", "reduced"}},
+	"lang":           {"xml:lang": nil},
+	"mark":           {"name": nil},
+	"phoneme":        {"alphabet": {"ipa", "x-sampa"}, "ph": nil},
+	"prosody":        {"rate": nil, "pitch": nil, "volume": nil},
+	"say-as":         {"interpret-as": nil, "format": nil},
+	"sub":            {"alias": nil},
+	"voice":          {"name": nil},
+	"w":              {"role": nil},
+	"amazon:domain":  {"name": {"conversational", "fun", "long-form", "music", "news"}},
+	"amazon:effect":  {"name": {"whispered"}},
+	"amazon:emotion": {"name": {"excited", "disappointed"}, "intensity": {"low", "medium", "high"}},
+}
+
+// ssmlRequiredAttributes lists the attributes an element must have
+var ssmlRequiredAttributes = map[string][]string{
+	"audio":          {"src"},
+	"lang":           {"xml:lang"},
+	"mark":           {"name"},
+	"phoneme":        {"ph"},
+	"say-as":         {"interpret-as"},
+	"sub":            {"alias"},
+	"voice":          {"name"},
+	"w":              {"role"},
+	"amazon:domain":  {"name"},
+	"amazon:effect":  {"name"},
+	"amazon:emotion": {"name", "intensity"},
+}
+
+// SSMLError is returned by SSMLSpeech Validate when the SSML is not well formed or uses an element or attribute that
+// Alexa does not support
+type SSMLError struct {
+	// Element is the name of the offending element, or empty if the SSML could not be parsed
+	Element string
+
+	// Offset is the byte offset in the SSML at which the problem was found
+	Offset int64
+
+	// Reason describes the problem
+	Reason string
+}
+
+// Error implements the error interface for the SSMLError type
+func (err *SSMLError) Error() string {
+	if err.Element == "" {
+		return fmt.Sprintf("invalid SSML at offset %d: %s", err.Offset, err.Reason)
+	}
+	return fmt.Sprintf("invalid SSML at offset %d: <%s> %s", err.Offset, err.Element, err.Reason)
+}
+
+// Validate checks that the SSML is well formed, has a single speak root element, and only uses the elements,
+// attributes and attribute values supported by Alexa. A *SSMLError describing the first problem found is returned if
+// the SSML is not valid.
+func (t SSMLSpeech) Validate() error {
+	decoder := xml.NewDecoder(strings.NewReader(string(t)))
+	decoder.Strict = true
+
+	var stack []string
+	seenRoot := false
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return &SSMLError{Offset: offset, Reason: err.Error()}
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			name := ssmlName(token.Name)
+			if len(stack) == 0 {
+				if seenRoot {
+					return &SSMLError{Element: name, Offset: offset, Reason: "must be inside the speak element"}
+				}
+				if name != "speak" {
+					return &SSMLError{Element: name, Offset: offset, Reason: "root element must be speak"}
+				}
+				seenRoot = true
+			} else if name == "speak" {
+				return &SSMLError{Element: name, Offset: offset, Reason: "cannot be nested"}
+			}
+			if err := validateSSMLElement(name, token.Attr); err != nil {
+				err.Offset = offset
+				return err
+			}
+			stack = append(stack, name)
+
+		case xml.EndElement:
+			name := ssmlName(token.Name)
+			if len(stack) == 0 || stack[len(stack)-1] != name {
+				return &SSMLError{Element: name, Offset: offset, Reason: "closes an element which is not open"}
+			}
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			if len(stack) == 0 && len(bytes.TrimSpace(token)) > 0 {
+				return &SSMLError{Offset: offset, Reason: "text must be inside the speak element"}
+			}
+
+		case xml.Directive:
+			return &SSMLError{Offset: offset, Reason: "directives are not supported"}
+		}
+	}
+
+	switch {
+	case !seenRoot:
+		return &SSMLError{Offset: decoder.InputOffset(), Reason: "missing speak element"}
+	case len(stack) > 0:
+		return &SSMLError{Element: stack[len(stack)-1], Offset: decoder.InputOffset(), Reason: "is not closed"}
+	}
+	return nil
+}
+
+// validateSSMLElement checks that the element name is supported, and that its attributes are supported and valid
+func validateSSMLElement(name string, attributes []xml.Attr) *SSMLError {
+	supported, ok := ssmlAttributes[name]
+	if !ok {
+		return &SSMLError{Element: name, Reason: "is not supported"}
+	}
+
+	present := map[string]bool{}
+	for _, attribute := range attributes {
+		attributeName := ssmlName(attribute.Name)
+		values, ok := supported[attributeName]
+		if !ok {
+			return &SSMLError{Element: name, Reason: fmt.Sprintf("attribute %s is not supported", attributeName)}
+		}
+		if values != nil && !containsString(values, attribute.Value) {
+			return &SSMLError{Element: name, Reason: fmt.Sprintf("attribute %s value %q is not one of %s",
+				attributeName, attribute.Value, strings.Join(values, ", "))}
+		}
+		present[attributeName] = true
+	}
+
+	for _, required := range ssmlRequiredAttributes[name] {
+		if !present[required] {
+			return &SSMLError{Element: name, Reason: fmt.Sprintf("attribute %s is required", required)}
+		}
+	}
+	return nil
+}
+
+// ssmlName returns the name of an element or attribute including its prefix, such as amazon:effect
+func ssmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// SSMLContent writes content inside an element created by an SSMLBuilder. Use SSMLText for plain text, or a function
+// calling the builder methods to nest elements.
+type SSMLContent func(builder *SSMLBuilder)
+
+// SSMLText returns SSMLContent writing text, escaped so that it cannot be interpreted as markup
+func SSMLText(text string) SSMLContent {
+	return func(builder *SSMLBuilder) {
+		builder.Say(text)
+	}
+}
+
+// SSMLBuilder builds SSMLSpeech, escaping any text and attribute values so that the result is well formed. Methods
+// return the builder so that calls can be chained, for example:
+//
+//	speech := alexa.NewSSMLBuilder().
+//	    Say("Your table is booked.").
+//	    Pause(500 * time.Millisecond).
+//	    Emphasis(alexa.SSMLEmphasisStrong, alexa.SSMLText("Enjoy your meal!")).
+//	    Build()
+type SSMLBuilder struct {
+	buffer bytes.Buffer
+}
+
+// NewSSMLBuilder returns an empty SSMLBuilder
+func NewSSMLBuilder() *SSMLBuilder {
+	return &SSMLBuilder{}
+}
+
+// Build returns the SSML written so far wrapped in a speak element
+func (builder *SSMLBuilder) Build() SSMLSpeech {
+	return SSMLSpeech("<speak>" + builder.buffer.String() + "</speak>")
+}
+
+// Say writes text
+func (builder *SSMLBuilder) Say(text string) *SSMLBuilder {
+	xml.EscapeText(&builder.buffer, []byte(text))
+	return builder
+}
+
+// Pause writes a pause of duration d, rounded down to the millisecond. Alexa supports pauses of up to 10 seconds
+func (builder *SSMLBuilder) Pause(d time.Duration) *SSMLBuilder {
+	return builder.element("break", []string{"time", fmt.Sprintf("%dms", d/time.Millisecond)}, nil)
+}
+
+// Break writes a pause of the given strength
+func (builder *SSMLBuilder) Break(strength SSMLBreakStrength) *SSMLBuilder {
+	return builder.element("break", []string{"strength", string(strength)}, nil)
+}
+
+// Paragraph writes content as a paragraph, with a pause before and after
+func (builder *SSMLBuilder) Paragraph(content SSMLContent) *SSMLBuilder {
+	return builder.element("p", nil, content)
+}
+
+// Sentence writes content as a sentence, with a pause before and after
+func (builder *SSMLBuilder) Sentence(content SSMLContent) *SSMLBuilder {
+	return builder.element("s", nil, content)
+}
+
+// Emphasis writes content with the given level of emphasis
+func (builder *SSMLBuilder) Emphasis(level SSMLEmphasisLevel, content SSMLContent) *SSMLBuilder {
+	return builder.element("emphasis", []string{"level", string(level)}, content)
+}
+
+// Prosody writes content with a modified rate, pitch and volume, such as "slow", "+10%" or "loud". Empty values are
+// left unchanged
+func (builder *SSMLBuilder) Prosody(rate, pitch, volume string, content SSMLContent) *SSMLBuilder {
+	var attributes []string
+	for _, attribute := range [][2]string{{"rate", rate}, {"pitch", pitch}, {"volume", volume}} {
+		if attribute[1] != "" {
+			attributes = append(attributes, attribute[0], attribute[1])
+		}
+	}
+	return builder.element("prosody", attributes, content)
+}
+
+// SayAs writes text interpreted as interpretAs, such as "digits", "date" or "interjection". format is optional and
+// only used by some interpretations, such as "mdy" for a date
+func (builder *SSMLBuilder) SayAs(interpretAs, format, text string) *SSMLBuilder {
+	attributes := []string{"interpret-as", interpretAs}
+	if format != "" {
+		attributes = append(attributes, "format", format)
+	}
+	return builder.element("say-as", attributes, SSMLText(text))
+}
+
+// Phoneme writes text pronounced using the phonetic pronunciation ph in alphabet, either "ipa" or "x-sampa"
+func (builder *SSMLBuilder) Phoneme(alphabet, ph, text string) *SSMLBuilder {
+	return builder.element("phoneme", []string{"alphabet", alphabet, "ph", ph}, SSMLText(text))
+}
+
+// Sub writes text, which is spoken as alias
+func (builder *SSMLBuilder) Sub(alias, text string) *SSMLBuilder {
+	return builder.element("sub", []string{"alias", alias}, SSMLText(text))
+}
+
+// Audio writes an audio clip played from the HTTPS URL src
+func (builder *SSMLBuilder) Audio(src string) *SSMLBuilder {
+	return builder.element("audio", []string{"src", src}, nil)
+}
+
+// Voice writes content spoken by the Amazon Polly voice name, such as "Brian"
+func (builder *SSMLBuilder) Voice(name string, content SSMLContent) *SSMLBuilder {
+	return builder.element("voice", []string{"name", name}, content)
+}
+
+// Lang writes content pronounced for the language lang, such as "fr-FR"
+func (builder *SSMLBuilder) Lang(lang string, content SSMLContent) *SSMLBuilder {
+	return builder.element("lang", []string{"xml:lang", lang}, content)
+}
+
+// Whisper writes content as a whisper, using the amazon:effect element
+func (builder *SSMLBuilder) Whisper(content SSMLContent) *SSMLBuilder {
+	return builder.element("amazon:effect", []string{"name", "whispered"}, content)
+}
+
+// Emotion writes content expressing emotion at the given intensity
+func (builder *SSMLBuilder) Emotion(emotion SSMLEmotion, intensity SSMLIntensity, content SSMLContent) *SSMLBuilder {
+	return builder.element("amazon:emotion", []string{"name", string(emotion), "intensity", string(intensity)}, content)
+}
+
+// Domain writes content in the speaking style of domain
+func (builder *SSMLBuilder) Domain(domain SSMLDomain, content SSMLContent) *SSMLBuilder {
+	return builder.element("amazon:domain", []string{"name", string(domain)}, content)
+}
+
+// element writes the element name with attributes, given as name and value pairs, around content. The element is
+// self closing if content is nil
+func (builder *SSMLBuilder) element(name string, attributes []string, content SSMLContent) *SSMLBuilder {
+	builder.buffer.WriteString("<" + name)
+	for i := 0; i+1 < len(attributes); i += 2 {
+		builder.buffer.WriteString(" " + attributes[i] + `="`)
+		xml.EscapeText(&builder.buffer, []byte(attributes[i+1]))
+		builder.buffer.WriteString(`"`)
+	}
+
+	if content == nil {
+		builder.buffer.WriteString("/>")
+		return builder
+	}
+
+	builder.buffer.WriteString(">")
+	content(builder)
+	builder.buffer.WriteString("</" + name + ">")
+	return builder
+}
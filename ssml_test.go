@@ -0,0 +1,92 @@
+package alexa
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSSMLBuilder(t *testing.T) {
+	Convey(`Given I build SSML using every element`, t, func() {
+		speech := NewSSMLBuilder().
+			Say(`Fish & chips <today>.`).
+			Pause(500*time.Millisecond).
+			Break(SSMLBreakStrong).
+			Paragraph(func(builder *SSMLBuilder) {
+				builder.Sentence(SSMLText(`First.`)).Sentence(SSMLText(`Second.`))
+			}).
+			Emphasis(SSMLEmphasisStrong, SSMLText(`really`)).
+			Prosody(`slow`, ``, `loud`, SSMLText(`slowly`)).
+			SayAs(`date`, `mdy`, `10/18/2026`).
+			Phoneme(`ipa`, `pɪˈkɑːn`, `pecan`).
+			Sub(`aluminium`, `Al`).
+			Audio(`https://example.com/a.mp3?x=1&y=2`).
+			Voice(`Brian`, SSMLText(`hello`)).
+			Lang(`fr-FR`, SSMLText(`bonjour`)).
+			Whisper(SSMLText(`secret`)).
+			Emotion(SSMLEmotionExcited, SSMLIntensityHigh, SSMLText(`hooray`)).
+			Domain(SSMLDomainNews, SSMLText(`headline`)).
+			Build()
+
+		Convey(`Then the text and attribute values will be escaped`, func() {
+			So(string(speech), ShouldStartWith, `<speak>Fish &amp; chips &lt;today&gt;.<break time="500ms"/>`)
+			So(string(speech), ShouldContainSubstring, `<audio src="https://example.com/a.mp3?x=1&amp;y=2"/>`)
+		})
+
+		Convey(`Then elements will be nested`, func() {
+			So(string(speech), ShouldContainSubstring, `<p><s>First.</s><s>Second.</s></p>`)
+			So(string(speech), ShouldContainSubstring, `<prosody rate="slow" volume="loud">slowly</prosody>`)
+			So(string(speech), ShouldContainSubstring, `<amazon:emotion name="excited" intensity="high">hooray</amazon:emotion>`)
+			So(string(speech), ShouldEndWith, `<amazon:domain name="news">headline</amazon:domain></speak>`)
+		})
+
+		Convey(`Then it will be valid`, func() {
+			So(speech.Validate(), ShouldBeNil)
+		})
+	})
+}
+
+func TestSSMLSpeechValidate(t *testing.T) {
+	invalid := map[string]*SSMLError{
+		``:                                    {Reason: `missing speak element`},
+		`Hello`:                               {Reason: `text must be inside the speak element`},
+		`<p>Hello</p>`:                        {Element: `p`, Reason: `root element must be speak`},
+		`<speak>Hello`:                        {Element: `speak`, Offset: 12, Reason: `is not closed`},
+		`<speak><speak/></speak>`:             {Element: `speak`, Offset: 7, Reason: `cannot be nested`},
+		`<speak><b>Hi</b></speak>`:            {Element: `b`, Offset: 7, Reason: `is not supported`},
+		`<speak><break length="1s"/></speak>`: {Element: `break`, Offset: 7, Reason: `attribute length is not supported`},
+		`<speak><audio/></speak>`:             {Element: `audio`, Offset: 7, Reason: `attribute src is required`},
+		`<speak><amazon:effect name="shout">x</amazon:effect></speak>`: {
+			Element: `amazon:effect`, Offset: 7, Reason: `attribute name value "shout" is not one of whispered`,
+		},
+		`<speak></speak><speak></speak>`: {Element: `speak`, Offset: 15, Reason: `must be inside the speak element`},
+	}
+
+	for ssml, expected := range invalid {
+		Convey(`When I validate the SSML `+ssml, t, func() {
+			err := SSMLSpeech(ssml).Validate()
+
+			Convey(`Then the offending element will be reported`, func() {
+				So(err, ShouldResemble, expected)
+			})
+		})
+	}
+
+	Convey(`When I validate SSML which is not well formed`, t, func() {
+		err := SSMLSpeech(`<speak><p>Hello</s></speak>`).Validate()
+
+		Convey(`Then an SSMLError will be returned`, func() {
+			So(err, ShouldHaveSameTypeAs, &SSMLError{})
+			So(err.Error(), ShouldStartWith, `invalid SSML at offset`)
+		})
+	})
+
+	Convey(`When I validate SSML using the lang element`, t, func() {
+		err := SSMLSpeech(`<speak><lang xml:lang="de-DE">Guten Tag</lang></speak>`).Validate()
+
+		Convey(`Then it will be valid`, func() {
+			So(err, ShouldBeNil)
+		})
+	})
+}
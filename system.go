@@ -0,0 +1,50 @@
+package alexa
+
+// SystemErrorType indicates the type of error reported in a SystemExceptionEncounteredRequest
+type SystemErrorType string
+
+const (
+	// SystemExceptionEncounteredRequestType is sent when a response from the skill caused an error
+	SystemExceptionEncounteredRequestType RequestTypeName = `System.ExceptionEncountered`
+
+	// SystemErrorTypeInvalidResponse indicates that the response was invalid, e.g. it contained a malformed directive
+	SystemErrorTypeInvalidResponse SystemErrorType = `INVALID_RESPONSE`
+	// SystemErrorTypeDeviceCommunicationError indicates a problem communicating with the device
+	SystemErrorTypeDeviceCommunicationError SystemErrorType = `DEVICE_COMMUNICATION_ERROR`
+	// SystemErrorTypeInternalServiceError indicates an error within the Alexa service
+	SystemErrorTypeInternalServiceError SystemErrorType = `INTERNAL_SERVICE_ERROR`
+)
+
+// SystemError describes the error reported in a SystemExceptionEncounteredRequest
+type SystemError struct {
+	// Type indicates the type of error that occurred
+	Type SystemErrorType `json:"type"`
+
+	// Message is a description of the error
+	Message string `json:"message"`
+}
+
+// SystemErrorCause identifies the request whose response caused the error
+type SystemErrorCause struct {
+	// RequestID is the ID of the request whose response caused the error
+	RequestID string `json:"requestId"`
+}
+
+// SystemExceptionEncounteredRequest is sent when a response from the skill, such as a directive sent in response to an
+// AudioPlayer request, caused an error.
+//
+// A skill cannot return output speech, a card or directives in response to a SystemExceptionEncounteredRequest.
+type SystemExceptionEncounteredRequest struct {
+	*BaseRequestType
+
+	// Error describes the error that occurred
+	Error *SystemError `json:"error"`
+
+	// Cause identifies the request whose response caused the error
+	Cause *SystemErrorCause `json:"cause"`
+}
+
+// GetType returns the SystemExceptionEncounteredRequestType
+func (request *SystemExceptionEncounteredRequest) GetType() RequestTypeName {
+	return SystemExceptionEncounteredRequestType
+}
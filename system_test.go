@@ -0,0 +1,35 @@
+package alexa
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSystemExceptionEncounteredRequestUnmarshal(t *testing.T) {
+	Convey(`When I unmarshal a System.ExceptionEncountered request`, t, func() {
+		request := &Request{}
+		err := json.Unmarshal([]byte(`{"version":"1.0","request":{"type":"System.ExceptionEncountered",`+
+			`"requestId":"amzn1.echo-api.request.1","timestamp":"2017-08-01T15:03:44Z","locale":"en-GB",`+
+			`"error":{"type":"INVALID_RESPONSE","message":"Invalid directive"},`+
+			`"cause":{"requestId":"amzn1.echo-api.request.0"}}}`), request)
+
+		Convey(`Then the error will be nil`, func() {
+			So(err, ShouldBeNil)
+		})
+
+		Convey(`Then the Request type will be correct`, func() {
+			So(request.Request, ShouldHaveSameTypeAs, &SystemExceptionEncounteredRequest{})
+			So(request.Request.GetType(), ShouldEqual, SystemExceptionEncounteredRequestType)
+			So(request.Request.GetID(), ShouldEqual, `amzn1.echo-api.request.1`)
+		})
+
+		Convey(`Then the error and cause will be set correctly`, func() {
+			exception := request.Request.(*SystemExceptionEncounteredRequest)
+			So(exception.Error.Type, ShouldEqual, SystemErrorTypeInvalidResponse)
+			So(exception.Error.Message, ShouldEqual, `Invalid directive`)
+			So(exception.Cause.RequestID, ShouldEqual, `amzn1.echo-api.request.0`)
+		})
+	})
+}
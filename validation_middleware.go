@@ -1,13 +1,15 @@
 package alexa
 
 import (
-	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
-	"encoding/json"
 	"encoding/pem"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -16,18 +18,46 @@ import (
 
 const (
 	// TimestampVerificationTolerance is the +/- tolerance in nanoseconds that an incoming Alexa request may have before
-	// it will be rejected by the RequestVerificationMiddleware
+	// it will be rejected by a Verifier, unless configured otherwise with WithTimestampTolerance
 	TimestampVerificationTolerance time.Duration = 150000000000
 
 	// certificateChainURL is the name of the header containing the request certificate
 	certificateChainURL = "SignatureCertChainUrl"
 
-	// signatureHeader is the name of the header on the request containing the encrypted signatureHeader.
+	// signatureHeader is the name of the header on the request containing the encrypted SHA-1 signature.
 	signatureHeader = "Signature"
 
+	// signature256Header is the name of the header on the request containing the encrypted SHA-256 signature. When
+	// present it is preferred over signatureHeader
+	signature256Header = "Signature-256"
+
 	validAlternativeName = "echo-api.amazon.com"
 )
 
+// SignatureMismatch is the error wrapped by a SignatureError when the asserted hash in the request signature does not
+// match the hash derived from the request body
+var SignatureMismatch = errors.New("signature does not match request body")
+
+// SignatureError is returned when the signature supplied on a request cannot be verified against the request body. Err
+// holds the underlying reason, which is SignatureMismatch when the signature was well formed but did not match.
+type SignatureError struct {
+	// Hash is the hash function the signature was verified with
+	Hash crypto.Hash
+
+	// Err is the underlying reason the signature was rejected
+	Err error
+}
+
+// Error implements the error interface for the SignatureError type
+func (err *SignatureError) Error() string {
+	return fmt.Sprintf("invalid %s signature: %s", err.Hash, err.Err)
+}
+
+// Unwrap returns the underlying reason the signature was rejected
+func (err *SignatureError) Unwrap() error {
+	return err.Err
+}
+
 // Package alexa requires that request are verified that they are by Alexa. Requests sent to your web service are
 // transmitted over the Internet. To protect your endpoint from potential attackers, your web service should verify that
 // incoming requests were sent by Alexa. Any requests coming from other sources should be rejected.
@@ -45,7 +75,8 @@ const (
 // Further information can be found at
 // https://developer.amazon.com/public/solutions/alexa/alexa-skills-kit/docs/developing-an-alexa-skill-as-a-web-service
 
-// RequestVerificationMiddleware verifies that a request was sent by Alexa
+// RequestVerificationMiddleware verifies that a request was sent by Alexa, using a Verifier with the default
+// configuration.
 //
 // Requests sent to your web service are transmitted over the Internet. To protect your endpoint from potential
 // attackers, your web service should verify that incoming requests were sent by Alexa. Any requests coming from other
@@ -54,136 +85,208 @@ const (
 // There are two parts to validating incoming requests:
 //   * Check the request signatureHeader to verify the authenticity of the request. Alexa signs all HTTPS requests.
 //   * Check the request timestamp to ensure that the request is not an old request being sent as part of a “replay” attack.
+//
+// Deprecated: Use NewVerifier and the Verifier Middleware method, which allow the verification policy to be
+// configured.
 func RequestVerificationMiddleware(next http.Handler) http.Handler {
-	// TODO: cache certificates?
-	// TODO: take application ID
-
-	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		body, err := ioutil.ReadAll(req.Body)
-		if err != nil {
-			http.Error(w, "Cannot read body", http.StatusBadRequest)
-			return
-		}
-		// Reset the request body
-		req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
-
-		fmt.Println(string(body))
-		request := &Request{}
-		if err := json.Unmarshal(body, request); err != nil {
-			http.Error(w, "err", http.StatusBadRequest)
-			return
-		}
-
-		if !TimestampInTolerance(request.Request.GetTimestamp()) {
-			http.Error(w, "Timestamp Not Recent", http.StatusBadRequest)
-			return
-		}
-
-		if !VerifySignatureCertificateURL(req.Header.Get(certificateChainURL)) {
-			http.Error(w, "Invalid Certificate Chain", http.StatusBadRequest)
-			return
-		}
-
-		if valid, err := ValidateCertificate(req.Header.Get(certificateChainURL), req.Header.Get(signatureHeader)); err != nil {
-			http.Error(w, fmt.Sprintf("Invalid Certificate: %s", err.Error()), http.StatusInternalServerError)
-		} else {
-			if !valid {
-				http.Error(w, "Invalid Certificate", http.StatusBadRequest)
-				return
-			}
-		}
-
-		next.ServeHTTP(w, req)
-	})
+	return NewVerifier().Middleware(next)
 }
 
 // TimestampInTolerance takes a timestamp and verifies that it is valid within the bounds of the
 // TimestampVerificationTolerance and it is not an old request being sent as part of a “replay” attack.
 //
 // This is required for certifying your Alexa skill and making it available to Amazon users.
+//
+// Deprecated: Use the Verifier TimestampInTolerance method, which allows the clock and tolerance to be configured.
 func TimestampInTolerance(timestamp time.Time) bool {
-	now := time.Now()
-	// true if timestamp less than (currentTime + Tolerance) and timestamp > (currentTime - Tolerance)
-	return timestamp.Unix() < now.Add(TimestampVerificationTolerance).Unix() && timestamp.Unix() > now.Add(-1*TimestampVerificationTolerance).Unix()
+	return NewVerifier().TimestampInTolerance(timestamp)
+}
+
+// CertificateURLError is returned by VerifySignatureCertificateURL when a certificate URL does not match the format
+// used by Amazon
+type CertificateURLError struct {
+	// URL is the rejected certificate URL
+	URL string
+
+	// Reason describes which rule the URL failed
+	Reason string
+}
+
+// Error implements the error interface for the CertificateURLError type
+func (err *CertificateURLError) Error() string {
+	return fmt.Sprintf("invalid certificate URL %q: %s", err.URL, err.Reason)
+}
+
+// CertificateChainError is returned by VerifyCertificateChain when a signing certificate chain cannot be trusted
+type CertificateChainError struct {
+	// Reason describes which check the certificate chain failed
+	Reason string
+
+	// Err is the underlying error, if any
+	Err error
+}
+
+// Error implements the error interface for the CertificateChainError type
+func (err *CertificateChainError) Error() string {
+	if err.Err == nil {
+		return fmt.Sprintf("invalid certificate chain: %s", err.Reason)
+	}
+	return fmt.Sprintf("invalid certificate chain: %s: %s", err.Reason, err.Err)
+}
+
+// Unwrap returns the underlying error
+func (err *CertificateChainError) Unwrap() error {
+	return err.Err
 }
 
 // VerifySignatureCertificateURL verifies the URL to ensure that it matches the format used by Amazon. This value can be
-// found specified by the SignatureCertChainUrl header value on the request
+// found specified by the SignatureCertChainUrl header value on the request. A *CertificateURLError describing the
+// failed rule is returned if the URL is invalid.
+//
+// The path is normalised by collapsing dot segments before it is checked, so that
+// https://s3.amazonaws.com/echo.api/../echo.api/echo-api-cert.pem is valid while
+// https://s3.amazonaws.com/echo.api/../invalid/echo-api-cert.pem is not.
 //
 // This is required for certifying your Alexa skill and making it available to Amazon users.
-func VerifySignatureCertificateURL(certificateURL string) bool {
+func VerifySignatureCertificateURL(certificateURL string) error {
 	parsed, err := url.Parse(certificateURL)
 	if err != nil {
-		return false
+		return &CertificateURLError{URL: certificateURL, Reason: "cannot be parsed"}
 	}
 
-	// TODO: Collapse and normalise the URL path
-
 	// The protocol is equal to https (case insensitive).
+	if !strings.EqualFold(parsed.Scheme, `https`) {
+		return &CertificateURLError{URL: certificateURL, Reason: fmt.Sprintf("scheme %q is not https", parsed.Scheme)}
+	}
+
 	// The hostname is equal to s3.amazonaws.com (case insensitive).
-	// The path starts with /echo.api/ (case sensitive).
+	if !strings.EqualFold(parsed.Hostname(), `s3.amazonaws.com`) {
+		return &CertificateURLError{URL: certificateURL, Reason: fmt.Sprintf("host %q is not s3.amazonaws.com", parsed.Hostname())}
+	}
+
 	// If a port is defined in the URL, the port is equal to 443.
-	if parsed.Scheme != `https` || parsed.Hostname() != `s3.amazonaws.com` ||
-		strings.Index(parsed.Path, `echo.api`) != 1 || parsed.Port() != "" && parsed.Port() != "443" {
-		return false
+	if parsed.Port() != "" && parsed.Port() != "443" {
+		return &CertificateURLError{URL: certificateURL, Reason: fmt.Sprintf("port %s is not 443", parsed.Port())}
 	}
-	return true
+
+	// The path starts with /echo.api/ (case sensitive), after dot segments have been collapsed.
+	if !strings.HasPrefix(normaliseURLPath(parsed.Path), `/echo.api/`) {
+		return &CertificateURLError{URL: certificateURL, Reason: fmt.Sprintf("path %q does not start with /echo.api/", parsed.Path)}
+	}
+	return nil
 }
 
-// ValidateCertificate verifies the signatureHeader in the HTTP headers
-func ValidateCertificate(certificateURL, signature string) (bool, error) {
-	// Download the PEM-encoded X.509 certificate chain that Alexa used to sign the message as specified by the
-	// certificateURL header value on the request.
-	certResponse, err := http.Get(certificateURL)
-	if err != nil {
-		return false, fmt.Errorf("Cannot download certificate")
+// ValidateCertificate downloads the certificate chain found at certificateURL and verifies that signature is a valid
+// signature of body, using a Verifier with the default configuration. See the Verifier ValidateCertificate method for
+// details.
+//
+// Deprecated: Use the Verifier ValidateCertificate method, which allows the HTTP client, root certificates and
+// certificate cache to be configured.
+func ValidateCertificate(certificateURL, signature string, hash crypto.Hash, body []byte) (bool, error) {
+	return NewVerifier().ValidateCertificate(certificateURL, signature, hash, body)
+}
+
+// ParseCertificateChain decodes every PEM encoded certificate in data, returning them in the order they were found. The
+// first certificate is expected to be the signing certificate, followed by any intermediate certificates.
+func ParseCertificateChain(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != `CERTIFICATE` {
+			continue
+		}
+		parsed, err := x509.ParseCertificates(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, parsed...)
 	}
 
-	certBody, err := ioutil.ReadAll(certResponse.Body)
-	if err != nil {
-		panic(err)
+	if len(certs) == 0 {
+		return nil, fmt.Errorf(`No PEM data found`)
 	}
+	return certs, nil
+}
 
-	// TODO: Handle the unused variable
-	block, _ := pem.Decode(certBody)
-	if block == nil {
-		return false, fmt.Errorf(`No PEM data found`)
+// VerifyCertificateChain verifies the certificate chain used by Alexa to sign a request, as returned by
+// ParseCertificateChain. The following checks are made:
+//   * The signing certificate has not expired at now (examining both the Not Before and Not After dates)
+//   * The domain echo-api.amazon.com is present in the Subject Alternative Names (SANs) section of the signing
+//     certificate
+//   * All certificates in the chain combine to create a chain of trust to a certificate in roots. If roots is nil, the
+//     system root certificates are used.
+//
+// A *CertificateChainError describing the failed check is returned if the chain cannot be trusted.
+func VerifyCertificateChain(certs []*x509.Certificate, roots *x509.CertPool, now time.Time) error {
+	if len(certs) == 0 {
+		return &CertificateChainError{Reason: "no certificates found"}
 	}
-	certs, err := x509.ParseCertificates(block.Bytes)
-	if err != nil {
-		return false, err
-	}
-
-	// TODO: Remove
-	// log.Println("TOTAL NUMBER OF CERTS: ", len(certs))
-	for _, cert := range certs {
-		// TODO:
-		// The signing certificate has not expired (examine both the Not Before and Not After dates)
-		// The domain echo-api.amazon.com is present in the Subject Alternative Names (SANs) section of the signing certificate
-		// All certificates in the chain combine to create a chain of trust to a trusted root CA certificate
-		if err := cert.VerifyHostname(validAlternativeName); err != nil {
-			return false, fmt.Errorf("Could not validate name %s: %s", validAlternativeName, err)
-		}
 
-		// Base64-decode the Signature header value on the request to obtain the encrypted signatureHeader.
-		//encryptedSig, err := base64.StdEncoding.DecodeString(signature)
-		_, err := base64.StdEncoding.DecodeString(signature)
-		if err != nil {
-			// TODO: Remove
-			// fg
-			// log.Println("Decrypt : ", err)
-			return false, err
-		}
+	signingCert := certs[0]
+	if now.Before(signingCert.NotBefore) || now.After(signingCert.NotAfter) {
+		return &CertificateChainError{Reason: fmt.Sprintf("signing certificate is not valid at %s: valid from %s until %s",
+			now.Format(time.RFC3339), signingCert.NotBefore.Format(time.RFC3339), signingCert.NotAfter.Format(time.RFC3339))}
+	}
 
-		// Use the public key extracted from the signing certificate to decrypt the encrypted signatureHeader to produce
-		// the asserted hash value.
-		// fmt.Println("Encryption key: ", cert.PublicKeyAlgorithm)
-		//publicKey := cert.PublicKey
+	if err := signingCert.VerifyHostname(validAlternativeName); err != nil {
+		return &CertificateChainError{Reason: fmt.Sprintf("could not validate name %s", validAlternativeName), Err: err}
+	}
 
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
 	}
 
-	// Generate a SHA-1 hash value from the full HTTPS request body to produce the derived hash value
+	if _, err := signingCert.Verify(x509.VerifyOptions{
+		DNSName:       validAlternativeName,
+		Intermediates: intermediates,
+		Roots:         roots,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return &CertificateChainError{Reason: "could not verify chain of trust", Err: err}
+	}
+	return nil
+}
 
-	// Compare the asserted hash value and derived hash values to ensure that they match.
-	return true, nil
+// VerifySignature checks that signature, the base64 encoded value of the Signature or Signature-256 header on the
+// request, was produced by the private key belonging to cert over body using the hash function hash. Only crypto.SHA1
+// and crypto.SHA256 are supported.
+//
+// A *SignatureError is returned if the signature cannot be verified.
+func VerifySignature(cert *x509.Certificate, signature string, hash crypto.Hash, body []byte) error {
+	// Generate a hash value from the full HTTPS request body to produce the derived hash value
+	var derived []byte
+	switch hash {
+	case crypto.SHA1:
+		sum := sha1.Sum(body)
+		derived = sum[:]
+	case crypto.SHA256:
+		sum := sha256.Sum256(body)
+		derived = sum[:]
+	default:
+		return &SignatureError{Hash: hash, Err: fmt.Errorf("unsupported hash function")}
+	}
+
+	// Base64-decode the Signature header value on the request to obtain the encrypted signature.
+	encryptedSig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return &SignatureError{Hash: hash, Err: err}
+	}
+
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return &SignatureError{Hash: hash, Err: fmt.Errorf("unsupported public key algorithm %s", cert.PublicKeyAlgorithm)}
+	}
+
+	// Use the public key extracted from the signing certificate to decrypt the encrypted signature to produce the
+	// asserted hash value, and compare it with the derived hash value to ensure that they match.
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, derived, encryptedSig); err != nil {
+		return &SignatureError{Hash: hash, Err: SignatureMismatch}
+	}
+	return nil
 }
@@ -3,8 +3,19 @@ package alexa
 import (
 	//	"bytes"
 	//	"encoding/json"
-	//	"net/http"
-	//	"net/http/httptest"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -159,10 +170,318 @@ func TestRequestVerificationMiddleware(t *testing.T) {
 	})
 }*/
 
-func TestValidateCertificate(t *testing.T) {
-	//if !alexa.ValidateCertificate(`https://s3.amazonaws.com/echo.api/echo-api-cert.pem`) {
-	//	t.Errorf("Invalid Certificate")
-	//}
+// testCertificateChain is a certificate chain issued by a test root certificate authority, as used by Alexa to sign
+// requests
+type testCertificateChain struct {
+	// key is the private key of the signing certificate
+	key *rsa.PrivateKey
+	// certs is the signing certificate followed by the intermediate certificate
+	certs []*x509.Certificate
+	// pem is the PEM encoding of certs
+	pem []byte
+	// roots contains the root certificate authority the chain was issued by
+	roots *x509.CertPool
+}
+
+// newTestCertificateChain generates a root and intermediate certificate authority, and a signing certificate for the
+// given DNS names which is valid between notBefore and notAfter
+func newTestCertificateChain(notBefore, notAfter time.Time, dnsNames ...string) *testCertificateChain {
+	issue := func(template, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+		key, err := rsa.GenerateKey(rand.Reader, 1024)
+		if err != nil {
+			panic(err)
+		}
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		if err != nil {
+			panic(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			panic(err)
+		}
+		return cert, key
+	}
+
+	caTemplate := func(serial int64, name string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: name},
+			NotBefore:             notBefore.Add(-24 * time.Hour),
+			NotAfter:              notAfter.Add(24 * time.Hour),
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign,
+		}
+	}
+
+	root, rootKey := issue(caTemplate(1, `Test Root CA`), nil, nil)
+	intermediate, intermediateKey := issue(caTemplate(2, `Test Intermediate CA`), root, rootKey)
+	leaf, leafKey := issue(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: `Test Signing Certificate`},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		DNSNames:     dnsNames,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, intermediate, intermediateKey)
+
+	chain := &testCertificateChain{
+		key:   leafKey,
+		certs: []*x509.Certificate{leaf, intermediate},
+		roots: x509.NewCertPool(),
+	}
+	chain.roots.AddCert(root)
+	for _, cert := range chain.certs {
+		chain.pem = append(chain.pem, pem.EncodeToMemory(&pem.Block{Type: `CERTIFICATE`, Bytes: cert.Raw})...)
+	}
+	return chain
+}
+
+// testSign signs body with key using the given hash function and returns the base64 encoded signature
+func testSign(key *rsa.PrivateKey, hash crypto.Hash, body []byte) string {
+	var digest []byte
+	switch hash {
+	case crypto.SHA1:
+		sum := sha1.Sum(body)
+		digest = sum[:]
+	case crypto.SHA256:
+		sum := sha256.Sum256(body)
+		digest = sum[:]
+	}
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, hash, digest)
+	if err != nil {
+		panic(err)
+	}
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestVerifySignature(t *testing.T) {
+	Convey(`Given I have a signing certificate and a request body`, t, func() {
+		chain := newTestCertificateChain(time.Now().Add(-1*time.Hour), time.Now().Add(time.Hour), validAlternativeName)
+		key, cert := chain.key, chain.certs[0]
+		body := []byte(`{"version":"1.0"}`)
+
+		Convey(`And I have a valid SHA-1 signature of the body`, func() {
+			signature := testSign(key, crypto.SHA1, body)
+
+			Convey(`When I call VerifySignature`, func() {
+				err := VerifySignature(cert, signature, crypto.SHA1, body)
+
+				Convey(`Then the error will be nil`, func() {
+					So(err, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey(`And I have a valid SHA-256 signature of the body`, func() {
+			signature := testSign(key, crypto.SHA256, body)
+
+			Convey(`When I call VerifySignature`, func() {
+				err := VerifySignature(cert, signature, crypto.SHA256, body)
+
+				Convey(`Then the error will be nil`, func() {
+					So(err, ShouldBeNil)
+				})
+			})
+		})
+
+		Convey(`And I have a signature of a different body`, func() {
+			signature := testSign(key, crypto.SHA256, []byte(`{"version":"2.0"}`))
+
+			Convey(`When I call VerifySignature`, func() {
+				err := VerifySignature(cert, signature, crypto.SHA256, body)
+
+				Convey(`Then the error will be a SignatureError`, func() {
+					So(err, ShouldHaveSameTypeAs, &SignatureError{})
+				})
+
+				Convey(`Then the error will wrap SignatureMismatch`, func() {
+					So(errors.Is(err, SignatureMismatch), ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey(`And I have a signature which is not base64 encoded`, func() {
+			signature := `not base64!`
+
+			Convey(`When I call VerifySignature`, func() {
+				err := VerifySignature(cert, signature, crypto.SHA1, body)
+
+				Convey(`Then the error will be a SignatureError`, func() {
+					So(err, ShouldHaveSameTypeAs, &SignatureError{})
+				})
+
+				Convey(`Then the error will not wrap SignatureMismatch`, func() {
+					So(errors.Is(err, SignatureMismatch), ShouldBeFalse)
+				})
+			})
+		})
+	})
+}
+
+func TestParseCertificateChain(t *testing.T) {
+	Convey(`Given I have a PEM encoded certificate chain`, t, func() {
+		chain := newTestCertificateChain(time.Now().Add(-1*time.Hour), time.Now().Add(time.Hour), validAlternativeName)
+
+		Convey(`When I call ParseCertificateChain`, func() {
+			certs, err := ParseCertificateChain(chain.pem)
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then every certificate in the chain will be returned in order`, func() {
+				So(len(certs), ShouldEqual, 2)
+				So(certs[0].Equal(chain.certs[0]), ShouldBeTrue)
+				So(certs[1].Equal(chain.certs[1]), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey(`Given I have data containing no PEM blocks`, t, func() {
+		data := []byte(`not a certificate`)
+
+		Convey(`When I call ParseCertificateChain`, func() {
+			_, err := ParseCertificateChain(data)
+
+			Convey(`Then the error will not be nil`, func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+}
+
+func TestVerifyCertificateChain(t *testing.T) {
+	now := time.Now()
+
+	Convey(`Given I have a valid certificate chain`, t, func() {
+		chain := newTestCertificateChain(now.Add(-1*time.Hour), now.Add(time.Hour), validAlternativeName)
+
+		Convey(`When I call VerifyCertificateChain with the issuing root`, func() {
+			err := VerifyCertificateChain(chain.certs, chain.roots, now)
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey(`When I call VerifyCertificateChain with a different root`, func() {
+			err := VerifyCertificateChain(chain.certs, x509.NewCertPool(), now)
+
+			Convey(`Then the error will be a CertificateChainError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateChainError{})
+			})
+		})
+
+		Convey(`When I call VerifyCertificateChain without the intermediate certificate`, func() {
+			err := VerifyCertificateChain(chain.certs[:1], chain.roots, now)
+
+			Convey(`Then the error will be a CertificateChainError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateChainError{})
+			})
+		})
+
+		Convey(`When I call VerifyCertificateChain after the signing certificate has expired`, func() {
+			err := VerifyCertificateChain(chain.certs, chain.roots, now.Add(2*time.Hour))
+
+			Convey(`Then the error will be a CertificateChainError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateChainError{})
+			})
+		})
+
+		Convey(`When I call VerifyCertificateChain before the signing certificate is valid`, func() {
+			err := VerifyCertificateChain(chain.certs, chain.roots, now.Add(-2*time.Hour))
+
+			Convey(`Then the error will be a CertificateChainError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateChainError{})
+			})
+		})
+	})
+
+	Convey(`Given I have a certificate chain without the Alexa alternative name`, t, func() {
+		chain := newTestCertificateChain(now.Add(-1*time.Hour), now.Add(time.Hour), `example.com`)
+
+		Convey(`When I call VerifyCertificateChain`, func() {
+			err := VerifyCertificateChain(chain.certs, chain.roots, now)
+
+			Convey(`Then the error will be a CertificateChainError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateChainError{})
+			})
+		})
+	})
+}
+
+func TestVerifier_ValidateCertificate(t *testing.T) {
+	Convey(`Given I have a trusted signing certificate chain served over HTTP`, t, func() {
+		chain := newTestCertificateChain(time.Now().Add(-1*time.Hour), time.Now().Add(time.Hour), validAlternativeName)
+		key := chain.key
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write(chain.pem)
+		}))
+		defer server.Close()
+
+		verifier := NewVerifier(
+			WithRootCertificates(chain.roots),
+			WithCertificateCache(NewMemoryCertificateCache(DefaultCertificateCacheSize)),
+		)
+
+		body := []byte(`{"version":"1.0"}`)
+
+		Convey(`When I call the Verifier ValidateCertificate with a valid signature`, func() {
+			valid, err := verifier.ValidateCertificate(server.URL, testSign(key, crypto.SHA1, body), crypto.SHA1, body)
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the result will be true`, func() {
+				So(valid, ShouldBeTrue)
+			})
+		})
+
+		Convey(`When I call the Verifier ValidateCertificate with a tampered body`, func() {
+			valid, err := verifier.ValidateCertificate(server.URL, testSign(key, crypto.SHA1, body), crypto.SHA1, []byte(`{}`))
+
+			Convey(`Then the error will wrap SignatureMismatch`, func() {
+				So(errors.Is(err, SignatureMismatch), ShouldBeTrue)
+			})
+
+			Convey(`Then the result will be false`, func() {
+				So(valid, ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey(`Given I have a signing certificate chain which is not trusted served over HTTP`, t, func() {
+		chain := newTestCertificateChain(time.Now().Add(-1*time.Hour), time.Now().Add(time.Hour), validAlternativeName)
+		key := chain.key
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write(chain.pem)
+		}))
+		defer server.Close()
+
+		verifier := NewVerifier(
+			WithRootCertificates(x509.NewCertPool()),
+			WithCertificateCache(NewMemoryCertificateCache(DefaultCertificateCacheSize)),
+		)
+
+		body := []byte(`{"version":"1.0"}`)
+
+		Convey(`When I call the Verifier ValidateCertificate with a valid signature`, func() {
+			valid, err := verifier.ValidateCertificate(server.URL, testSign(key, crypto.SHA1, body), crypto.SHA1, body)
+
+			Convey(`Then the error will not be nil`, func() {
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey(`Then the result will be false`, func() {
+				So(valid, ShouldBeFalse)
+			})
+		})
+	})
 }
 
 func TestTimestampInTolerance(t *testing.T) {
@@ -220,10 +539,10 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `https://s3.amazonaws.com/echo.api/echo-api-cert.pem`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be true`, func() {
-				So(result, ShouldBeTrue)
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
 			})
 		})
 	})
@@ -232,10 +551,10 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `https://s3.amazonaws.com:443/echo.api/echo-api-cert.pem`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be true`, func() {
-				So(result, ShouldBeTrue)
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
 			})
 		})
 	})
@@ -244,10 +563,10 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `https://s3.amazonaws.com/echo.api/../echo.api/echo-api-cert.pem`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be true`, func() {
-				So(result, ShouldBeTrue)
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
 			})
 		})
 	})
@@ -256,10 +575,10 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `http://s3.amazonaws.com/echo.api/echo-api-cert.pem`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be false`, func() {
-				So(result, ShouldBeFalse)
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
 			})
 		})
 	})
@@ -268,10 +587,10 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `https://notamazon.com/echo.api/echo-api-cert.pem`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be false`, func() {
-				So(result, ShouldBeFalse)
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
 			})
 		})
 	})
@@ -280,10 +599,10 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `https://s3.amazonaws.com/EcHo.aPi/echo-api-cert.pem`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be false`, func() {
-				So(result, ShouldBeFalse)
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
 			})
 		})
 	})
@@ -292,10 +611,10 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `https://s3.amazonaws.com/invalid.path/echo-api-cert.pem`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be false`, func() {
-				So(result, ShouldBeFalse)
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
 			})
 		})
 	})
@@ -304,10 +623,14 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `https://s3.amazonaws.com:563/echo.api/echo-api-cert.pem`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be false`, func() {
-				So(result, ShouldBeFalse)
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
+			})
+
+			Convey(`Then the error will describe the failed port rule`, func() {
+				So(err.Error(), ShouldContainSubstring, `port 563`)
 			})
 		})
 	})
@@ -316,10 +639,62 @@ func TestVerifySignatureCertificateURL(t *testing.T) {
 		path := `definitely not a URL`
 
 		Convey(`When I call VerifySignatureCertificateURL`, func() {
-			result := VerifySignatureCertificateURL(path)
+			err := VerifySignatureCertificateURL(path)
 
-			Convey(`Then the result will be false`, func() {
-				So(result, ShouldBeFalse)
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
+			})
+		})
+	})
+
+	Convey(`Given I have a valid certificate URL with an upper case scheme and hostname`, t, func() {
+		path := `HTTPS://S3.AmazonAWS.COM/echo.api/echo-api-cert.pem`
+
+		Convey(`When I call VerifySignatureCertificateURL`, func() {
+			err := VerifySignatureCertificateURL(path)
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+		})
+	})
+
+	Convey(`Given I have a certificate URL whose path leaves echo.api once normalized`, t, func() {
+		path := `https://s3.amazonaws.com/echo.api/../evil/cert.pem`
+
+		Convey(`When I call VerifySignatureCertificateURL`, func() {
+			err := VerifySignatureCertificateURL(path)
+
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
+			})
+
+			Convey(`Then the error will describe the failed path rule`, func() {
+				So(err.Error(), ShouldContainSubstring, `/echo.api/`)
+			})
+		})
+	})
+
+	Convey(`Given I have a certificate URL whose path leaves echo.api using encoded dot segments`, t, func() {
+		path := `https://s3.amazonaws.com/echo.api/%2e%2e/evil/cert.pem`
+
+		Convey(`When I call VerifySignatureCertificateURL`, func() {
+			err := VerifySignatureCertificateURL(path)
+
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
+			})
+		})
+	})
+
+	Convey(`Given I have a certificate URL whose path only contains echo.api as a prefix of a segment`, t, func() {
+		path := `https://s3.amazonaws.com/echo.api.evil/cert.pem`
+
+		Convey(`When I call VerifySignatureCertificateURL`, func() {
+			err := VerifySignatureCertificateURL(path)
+
+			Convey(`Then the error will be a CertificateURLError`, func() {
+				So(err, ShouldHaveSameTypeAs, &CertificateURLError{})
 			})
 		})
 	})
@@ -0,0 +1,351 @@
+package alexa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+)
+
+// DefaultMaxBodySize is the largest request body, in bytes, that a Verifier will read before rejecting the request
+const DefaultMaxBodySize int64 = 1 << 20
+
+// Verifier verifies that requests were sent by Alexa. The zero value is not usable, a Verifier should be created with
+// NewVerifier and configured with VerifierOptions. A Verifier is safe for concurrent use, and several Verifiers with
+// different policies may be used in one process.
+type Verifier struct {
+	now            func() time.Time
+	client         *http.Client
+	tolerance      time.Duration
+	roots          *x509.CertPool
+	cache          CertificateCache
+	logger         *log.Logger
+	maxBodySize    int64
+	applicationIDs map[string]bool
+	certificates   certificateLoader
+}
+
+// ApplicationIDError is returned when a request is for an application ID which is not one of the application IDs
+// allowed by a Verifier
+type ApplicationIDError struct {
+	// ApplicationID is the rejected application ID. It is empty if the request did not contain an application ID
+	ApplicationID string
+}
+
+// Error implements the error interface for the ApplicationIDError type
+func (err *ApplicationIDError) Error() string {
+	if err.ApplicationID == "" {
+		return "request does not contain an application ID"
+	}
+	return fmt.Sprintf("application ID %s is not allowed", err.ApplicationID)
+}
+
+// VerifierOption configures a Verifier when passed to NewVerifier
+type VerifierOption func(*Verifier)
+
+// NewVerifier returns a Verifier configured with the passed options. Unless overridden the Verifier uses time.Now,
+// http.DefaultClient, the TimestampVerificationTolerance, the system root certificates, its own MemoryCertificateCache
+// holding DefaultCertificateCacheSize chains and DefaultMaxBodySize, and does not log.
+func NewVerifier(options ...VerifierOption) *Verifier {
+	verifier := &Verifier{
+		now:         time.Now,
+		client:      http.DefaultClient,
+		tolerance:   TimestampVerificationTolerance,
+		logger:      log.New(ioutil.Discard, "", 0),
+		maxBodySize: DefaultMaxBodySize,
+	}
+	// The cache is not shared with other Verifiers, as a chain is only verified against the roots of the Verifier that
+	// downloaded it, and expires chains using the Verifier clock
+	cache := NewMemoryCertificateCache(DefaultCertificateCacheSize)
+	cache.Now = func() time.Time { return verifier.now() }
+	verifier.cache = cache
+	for _, option := range options {
+		option(verifier)
+	}
+	return verifier
+}
+
+// WithClock sets the function used to determine the current time when checking request timestamps and certificate
+// validity
+func WithClock(now func() time.Time) VerifierOption {
+	return func(verifier *Verifier) {
+		verifier.now = now
+	}
+}
+
+// WithHTTPClient sets the client used to download signing certificate chains
+func WithHTTPClient(client *http.Client) VerifierOption {
+	return func(verifier *Verifier) {
+		verifier.client = client
+	}
+}
+
+// WithTimestampTolerance sets the +/- tolerance that a request timestamp may differ from the current time before the
+// request is rejected
+func WithTimestampTolerance(tolerance time.Duration) VerifierOption {
+	return func(verifier *Verifier) {
+		verifier.tolerance = tolerance
+	}
+}
+
+// WithRootCertificates sets the pool of root certificate authorities that the signing certificate chain must chain
+// to. If roots is nil, the system root certificates are used.
+func WithRootCertificates(roots *x509.CertPool) VerifierOption {
+	return func(verifier *Verifier) {
+		verifier.roots = roots
+	}
+}
+
+// WithCertificateCache sets the cache used to hold verified signing certificate chains. If cache is nil, chains are
+// downloaded for every request.
+//
+// Cached chains are not verified again, so a cache should only be shared between Verifiers which use the same root
+// certificates.
+func WithCertificateCache(cache CertificateCache) VerifierOption {
+	return func(verifier *Verifier) {
+		verifier.cache = cache
+	}
+}
+
+// WithLogger sets the logger that the reasons for rejecting requests are written to
+func WithLogger(logger *log.Logger) VerifierOption {
+	return func(verifier *Verifier) {
+		verifier.logger = logger
+	}
+}
+
+// WithMaxBodySize sets the largest request body, in bytes, that will be read before the request is rejected
+func WithMaxBodySize(size int64) VerifierOption {
+	return func(verifier *Verifier) {
+		verifier.maxBodySize = size
+	}
+}
+
+// WithApplicationIDs sets the application IDs of the skills that the Verifier accepts requests for. Requests for any
+// other skill are rejected with http.StatusForbidden. If no application IDs are set, requests are accepted regardless
+// of their application ID, so calling WithApplicationIDs with no application IDs leaves the Verifier accepting any skill.
+func WithApplicationIDs(applicationIDs ...string) VerifierOption {
+	return func(verifier *Verifier) {
+		if len(applicationIDs) == 0 {
+			verifier.applicationIDs = nil
+			return
+		}
+		verifier.applicationIDs = make(map[string]bool, len(applicationIDs))
+		for _, applicationID := range applicationIDs {
+			verifier.applicationIDs[applicationID] = true
+		}
+	}
+}
+
+// Middleware returns a http.Handler which verifies that a request was sent by Alexa before passing it to next.
+//
+// Requests sent to your web service are transmitted over the Internet. To protect your endpoint from potential
+// attackers, your web service should verify that incoming requests were sent by Alexa. Any requests coming from other
+// sources should be rejected.
+//
+// There are two parts to validating incoming requests:
+//   * Check the request signature to verify the authenticity of the request. Alexa signs all HTTPS requests.
+//   * Check the request timestamp to ensure that the request is not an old request being sent as part of a “replay” attack.
+//
+// If the Verifier was configured with WithApplicationIDs, the request must also be for one of those skills. The matched
+// application ID is available to next with ApplicationIDFromContext.
+//
+// The decoded request and the raw request body are available to next with RequestFromContext and
+// RequestBodyFromContext, so the body does not need to be decoded again. The request body is also reset so that it can
+// still be read by next.
+func (verifier *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, req.Body, verifier.maxBodySize))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				verifier.reject(w, http.StatusRequestEntityTooLarge, "Request Too Large", err)
+				return
+			}
+			verifier.reject(w, http.StatusBadRequest, "Cannot read body", err)
+			return
+		}
+		// Reset the request body
+		req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+
+		request := &Request{}
+		if err := json.Unmarshal(body, request); err != nil {
+			verifier.reject(w, http.StatusBadRequest, "Invalid Request", err)
+			return
+		}
+
+		if request.Request == nil || request.Request.GetTimestamp().IsZero() ||
+			!verifier.TimestampInTolerance(request.Request.GetTimestamp()) {
+			verifier.reject(w, http.StatusBadRequest, "Timestamp Not Recent", nil)
+			return
+		}
+
+		if err := VerifySignatureCertificateURL(req.Header.Get(certificateChainURL)); err != nil {
+			verifier.reject(w, http.StatusBadRequest, "Invalid Certificate Chain", err)
+			return
+		}
+
+		signature, hash := req.Header.Get(signature256Header), crypto.SHA256
+		if signature == "" {
+			signature, hash = req.Header.Get(signatureHeader), crypto.SHA1
+		}
+
+		if valid, err := verifier.ValidateCertificate(req.Header.Get(certificateChainURL), signature, hash, body); err != nil {
+			var signatureErr *SignatureError
+			if errors.As(err, &signatureErr) {
+				verifier.reject(w, http.StatusBadRequest, "Invalid Signature", err)
+				return
+			}
+			var chainErr *CertificateChainError
+			if errors.As(err, &chainErr) {
+				verifier.reject(w, http.StatusBadRequest, "Invalid Certificate", err)
+				return
+			}
+			verifier.reject(w, http.StatusInternalServerError, "Cannot Verify Certificate", err)
+			return
+		} else if !valid {
+			verifier.reject(w, http.StatusBadRequest, "Invalid Certificate", nil)
+			return
+		}
+
+		ctx := NewContext(req.Context(), request, body)
+		if verifier.applicationIDs != nil {
+			applicationID, err := verifier.VerifyApplicationID(request)
+			if err != nil {
+				verifier.reject(w, http.StatusForbidden, "Invalid Application ID", err)
+				return
+			}
+			ctx = withApplicationID(ctx, applicationID)
+		}
+
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// VerifyApplicationID checks that the application ID in the request context, and in the request session when present,
+// is one of the application IDs allowed by the Verifier, returning the matched application ID. An
+// *ApplicationIDError is returned if the request is for any other skill.
+//
+// If the Verifier was not configured with WithApplicationIDs, any application ID is allowed. An *ApplicationIDError is
+// still returned if the request has no application ID, or if the context and session application IDs differ.
+func (verifier *Verifier) VerifyApplicationID(request *Request) (string, error) {
+	var applicationIDs []string
+	if request.Context != nil && request.Context.System != nil {
+		applicationIDs = append(applicationIDs, request.Context.System.ApplicationID)
+	}
+	if request.Session != nil {
+		applicationIDs = append(applicationIDs, request.Session.ApplicationID)
+	}
+	if len(applicationIDs) == 0 {
+		return "", &ApplicationIDError{}
+	}
+
+	for _, applicationID := range applicationIDs {
+		if applicationID == "" || verifier.applicationIDs != nil && !verifier.applicationIDs[applicationID] {
+			return "", &ApplicationIDError{ApplicationID: applicationID}
+		}
+		if applicationID != applicationIDs[0] {
+			return "", &ApplicationIDError{ApplicationID: applicationID}
+		}
+	}
+	return applicationIDs[0], nil
+}
+
+// reject logs the reason a request was rejected and writes the error message and status code to w
+func (verifier *Verifier) reject(w http.ResponseWriter, code int, message string, err error) {
+	if err != nil {
+		verifier.logger.Printf("alexa: rejected request (%d %s): %s", code, message, err)
+	} else {
+		verifier.logger.Printf("alexa: rejected request (%d %s)", code, message)
+	}
+	http.Error(w, message, code)
+}
+
+// TimestampInTolerance takes a timestamp and verifies that it is valid within the bounds of the Verifier tolerance
+// and it is not an old request being sent as part of a “replay” attack.
+//
+// This is required for certifying your Alexa skill and making it available to Amazon users.
+func (verifier *Verifier) TimestampInTolerance(timestamp time.Time) bool {
+	now := verifier.now()
+	// true if timestamp less than (currentTime + Tolerance) and timestamp > (currentTime - Tolerance)
+	return timestamp.Unix() < now.Add(verifier.tolerance).Unix() && timestamp.Unix() > now.Add(-1*verifier.tolerance).Unix()
+}
+
+// ValidateCertificate downloads the certificate chain found at certificateURL, verifies it with VerifyCertificateChain
+// against the Verifier root certificates and uses the public key of the signing certificate to verify that signature
+// is a valid signature of body. The signature is the base64 encoded value of the Signature (hash of crypto.SHA1) or
+// Signature-256 (hash of crypto.SHA256) header on the request, and body is the full, unmodified HTTPS request body.
+//
+// Verified certificate chains are held in the Verifier certificate cache, so the chain is only downloaded again once
+// the signing certificate has expired or been evicted.
+//
+// A *SignatureError is returned if the signature cannot be decoded or does not match the body.
+func (verifier *Verifier) ValidateCertificate(certificateURL, signature string, hash crypto.Hash, body []byte) (bool, error) {
+	certs, err := verifier.loadCertificateChain(certificateURL)
+	if err != nil {
+		return false, err
+	}
+
+	// The first certificate in the chain is the signing certificate
+	signingCert := certs[0]
+
+	if err := VerifySignature(signingCert, signature, hash, body); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// loadCertificateChain returns the verified certificate chain found at certificateURL, using the certificate cache
+// when it holds the chain. Concurrent requests for the same uncached chain result in a single download.
+func (verifier *Verifier) loadCertificateChain(certificateURL string) ([]*x509.Certificate, error) {
+	if verifier.cache != nil {
+		// The cache may not use the Verifier clock, so expired chains are checked for here as well
+		if certs, found := verifier.cache.Get(certificateURL); found && len(certs) > 0 &&
+			!verifier.now().After(certificateChainNotAfter(certs)) {
+			return certs, nil
+		}
+	}
+
+	return verifier.certificates.load(normaliseCertificateURL(certificateURL), func() ([]*x509.Certificate, error) {
+		certs, err := verifier.downloadCertificateChain(certificateURL)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := VerifyCertificateChain(certs, verifier.roots, verifier.now()); err != nil {
+			return nil, err
+		}
+
+		if verifier.cache != nil {
+			verifier.cache.Set(certificateURL, certs)
+		}
+		return certs, nil
+	})
+}
+
+// downloadCertificateChain downloads and parses the PEM-encoded X.509 certificate chain that Alexa used to sign the
+// message as specified by the SignatureCertChainUrl header value on the request.
+func (verifier *Verifier) downloadCertificateChain(certificateURL string) ([]*x509.Certificate, error) {
+	certResponse, err := verifier.client.Get(certificateURL)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot download certificate")
+	}
+	defer certResponse.Body.Close()
+
+	if certResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Cannot download certificate: %s", certResponse.Status)
+	}
+
+	certBody, err := ioutil.ReadAll(certResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read certificate: %s", err)
+	}
+
+	return ParseCertificateChain(certBody)
+}
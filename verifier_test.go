@@ -0,0 +1,480 @@
+package alexa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testCertificateTransport is a http.RoundTripper which responds to every request with a certificate chain
+type testCertificateTransport struct {
+	pem      []byte
+	requests int
+}
+
+func (transport *testCertificateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.requests++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewReader(transport.pem)),
+		Request:    req,
+	}, nil
+}
+
+// testSignedRequest returns a HTTP request for body signed by chain with the given hash function, as Alexa would send it
+func testSignedRequest(chain *testCertificateChain, hash crypto.Hash, body []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, `/`, bytes.NewReader(body))
+	req.Header.Set(certificateChainURL, `https://s3.amazonaws.com/echo.api/echo-api-cert.pem`)
+	if hash == crypto.SHA256 {
+		req.Header.Set(signature256Header, testSign(chain.key, hash, body))
+	} else {
+		req.Header.Set(signatureHeader, testSign(chain.key, hash, body))
+	}
+	return req
+}
+
+func TestVerifier_Middleware(t *testing.T) {
+	now := time.Date(2017, 8, 1, 15, 3, 44, 0, time.UTC)
+	body := []byte(`{"version":"1.0","request":{"type":"LaunchRequest","requestId":"1","timestamp":"2017-08-01T15:03:44Z"}}`)
+
+	Convey(`Given I have a Verifier trusting a signing certificate chain`, t, func() {
+		chain := newTestCertificateChain(now.Add(-1*time.Hour), now.Add(time.Hour), validAlternativeName)
+		transport := &testCertificateTransport{pem: chain.pem}
+		clock := func() time.Time { return now }
+
+		cache := NewMemoryCertificateCache(DefaultCertificateCacheSize)
+		cache.Now = clock
+
+		verifier := NewVerifier(
+			WithClock(clock),
+			WithHTTPClient(&http.Client{Transport: transport}),
+			WithRootCertificates(chain.roots),
+			WithCertificateCache(cache),
+		)
+
+		Convey(`And I have an Alexa endpoint wrapped by the Verifier`, func() {
+			var endpointBody, contextBody []byte
+			var contextRequest *Request
+			endpointCalled := false
+			endpoint := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				endpointCalled = true
+				endpointBody, _ = ioutil.ReadAll(req.Body)
+				contextRequest, _ = RequestFromContext(req.Context())
+				contextBody, _ = RequestBodyFromContext(req.Context())
+			}))
+
+			Convey(`When I make a request signed with SHA-1`, func() {
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, body))
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the endpoint will have been called with the request body`, func() {
+					So(endpointCalled, ShouldBeTrue)
+					So(string(endpointBody), ShouldEqual, string(body))
+				})
+
+				Convey(`Then the decoded request will be on the request context`, func() {
+					So(contextRequest, ShouldNotBeNil)
+					So(contextRequest.Request.GetID(), ShouldEqual, `1`)
+				})
+
+				Convey(`Then the raw body will be on the request context`, func() {
+					So(string(contextBody), ShouldEqual, string(body))
+				})
+			})
+
+			Convey(`When I make two requests signed with SHA-256`, func() {
+				endpoint.ServeHTTP(httptest.NewRecorder(), testSignedRequest(chain, crypto.SHA256, body))
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA256, body))
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the certificate chain will only be downloaded once`, func() {
+					So(transport.requests, ShouldEqual, 1)
+				})
+			})
+
+			Convey(`When I make a request with a forged signature`, func() {
+				req := testSignedRequest(chain, crypto.SHA1, body)
+				req.Header.Set(signatureHeader, testSign(chain.key, crypto.SHA1, []byte(`forged`)))
+
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, req)
+
+				Convey(`Then the status code will be StatusBadRequest`, func() {
+					So(response.Code, ShouldEqual, http.StatusBadRequest)
+				})
+
+				Convey(`Then the endpoint will not have been called`, func() {
+					So(endpointCalled, ShouldBeFalse)
+				})
+			})
+
+			Convey(`When I make a request with an invalid certificate URL`, func() {
+				req := testSignedRequest(chain, crypto.SHA1, body)
+				req.Header.Set(certificateChainURL, `https://example.com/echo.api/echo-api-cert.pem`)
+
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, req)
+
+				Convey(`Then the status code will be StatusBadRequest`, func() {
+					So(response.Code, ShouldEqual, http.StatusBadRequest)
+				})
+
+				Convey(`Then the endpoint will not have been called`, func() {
+					So(endpointCalled, ShouldBeFalse)
+				})
+			})
+
+			Convey(`When I make a request without the common request fields`, func() {
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, []byte(`{"request":{"type":"LaunchRequest"}}`)))
+
+				Convey(`Then the status code will be StatusBadRequest`, func() {
+					So(response.Code, ShouldEqual, http.StatusBadRequest)
+				})
+
+				Convey(`Then the endpoint will not have been called`, func() {
+					So(endpointCalled, ShouldBeFalse)
+				})
+			})
+
+			Convey(`When I make a request which is not valid JSON`, func() {
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, []byte(`not json`)))
+
+				Convey(`Then the status code will be StatusBadRequest`, func() {
+					So(response.Code, ShouldEqual, http.StatusBadRequest)
+				})
+			})
+		})
+
+		Convey(`And the Verifier clock is later than the timestamp tolerance`, func() {
+			verifier := NewVerifier(
+				WithClock(func() time.Time { return now.Add(2 * TimestampVerificationTolerance) }),
+				WithHTTPClient(&http.Client{Transport: transport}),
+				WithRootCertificates(chain.roots),
+			)
+
+			Convey(`When I make a signed request`, func() {
+				endpointCalled := false
+				endpoint := verifier.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+					endpointCalled = true
+				}))
+
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, body))
+
+				Convey(`Then the status code will be StatusBadRequest`, func() {
+					So(response.Code, ShouldEqual, http.StatusBadRequest)
+				})
+
+				Convey(`Then the endpoint will not have been called`, func() {
+					So(endpointCalled, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey(`And the Verifier does not trust the signing certificate chain`, func() {
+			verifier := NewVerifier(
+				WithClock(clock),
+				WithHTTPClient(&http.Client{Transport: transport}),
+				WithRootCertificates(x509.NewCertPool()),
+			)
+
+			Convey(`When I make a signed request`, func() {
+				endpointCalled := false
+				endpoint := verifier.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+					endpointCalled = true
+				}))
+
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, body))
+
+				Convey(`Then the status code will be StatusBadRequest`, func() {
+					So(response.Code, ShouldEqual, http.StatusBadRequest)
+				})
+
+				Convey(`Then the response body will not contain the certificate error`, func() {
+					So(strings.TrimSpace(response.Body.String()), ShouldEqual, `Invalid Certificate`)
+				})
+
+				Convey(`Then the endpoint will not have been called`, func() {
+					So(endpointCalled, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey(`And the signing certificate chain has expired`, func() {
+			expiredChain := newTestCertificateChain(now.Add(-2*time.Hour), now.Add(-1*time.Hour), validAlternativeName)
+			verifier := NewVerifier(
+				WithClock(clock),
+				WithHTTPClient(&http.Client{Transport: &testCertificateTransport{pem: expiredChain.pem}}),
+				WithRootCertificates(expiredChain.roots),
+			)
+
+			Convey(`When I make a signed request`, func() {
+				endpointCalled := false
+				endpoint := verifier.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+					endpointCalled = true
+				}))
+
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(expiredChain, crypto.SHA1, body))
+
+				Convey(`Then the status code will be StatusBadRequest`, func() {
+					So(response.Code, ShouldEqual, http.StatusBadRequest)
+				})
+
+				Convey(`Then the response body will not contain the certificate error`, func() {
+					So(strings.TrimSpace(response.Body.String()), ShouldEqual, `Invalid Certificate`)
+				})
+
+				Convey(`Then the endpoint will not have been called`, func() {
+					So(endpointCalled, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey(`And the Verifier has a maximum body size smaller than the request`, func() {
+			verifier := NewVerifier(
+				WithClock(func() time.Time { return now }),
+				WithMaxBodySize(16),
+			)
+
+			Convey(`When I make a signed request`, func() {
+				endpoint := verifier.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, body))
+
+				Convey(`Then the status code will be StatusRequestEntityTooLarge`, func() {
+					So(response.Code, ShouldEqual, http.StatusRequestEntityTooLarge)
+				})
+			})
+		})
+	})
+}
+
+func TestVerifier_TimestampInTolerance(t *testing.T) {
+	now := time.Date(2017, 8, 1, 15, 3, 44, 0, time.UTC)
+
+	Convey(`Given I have a Verifier with a fixed clock and a tolerance of one minute`, t, func() {
+		verifier := NewVerifier(WithClock(func() time.Time { return now }), WithTimestampTolerance(time.Minute))
+
+		Convey(`When I call TimestampInTolerance with a timestamp within the tolerance`, func() {
+			result := verifier.TimestampInTolerance(now.Add(-30 * time.Second))
+
+			Convey(`Then the result will be true`, func() {
+				So(result, ShouldBeTrue)
+			})
+		})
+
+		Convey(`When I call TimestampInTolerance with a timestamp outside the tolerance`, func() {
+			result := verifier.TimestampInTolerance(now.Add(-2 * time.Minute))
+
+			Convey(`Then the result will be false`, func() {
+				So(result, ShouldBeFalse)
+			})
+		})
+	})
+}
+
+func TestVerifier_VerifyApplicationID(t *testing.T) {
+	Convey(`Given I have a Verifier allowing a single application ID`, t, func() {
+		verifier := NewVerifier(WithApplicationIDs(`amzn1.ask.skill.allowed`))
+
+		Convey(`When I verify a request whose context and session contain the allowed application ID`, func() {
+			applicationID, err := verifier.VerifyApplicationID(&Request{
+				Context: &Context{System: &System{ApplicationID: `amzn1.ask.skill.allowed`}},
+				Session: &RequestSession{ApplicationID: `amzn1.ask.skill.allowed`},
+			})
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the matched application ID will be returned`, func() {
+				So(applicationID, ShouldEqual, `amzn1.ask.skill.allowed`)
+			})
+		})
+
+		Convey(`When I verify a request without a session containing the allowed application ID`, func() {
+			applicationID, err := verifier.VerifyApplicationID(&Request{
+				Context: &Context{System: &System{ApplicationID: `amzn1.ask.skill.allowed`}},
+			})
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the matched application ID will be returned`, func() {
+				So(applicationID, ShouldEqual, `amzn1.ask.skill.allowed`)
+			})
+		})
+
+		Convey(`When I verify a request for another application ID`, func() {
+			_, err := verifier.VerifyApplicationID(&Request{
+				Context: &Context{System: &System{ApplicationID: `amzn1.ask.skill.other`}},
+			})
+
+			Convey(`Then the error will be an ApplicationIDError for the rejected ID`, func() {
+				So(err, ShouldResemble, &ApplicationIDError{ApplicationID: `amzn1.ask.skill.other`})
+			})
+		})
+
+		Convey(`When I verify a request whose session application ID is not allowed`, func() {
+			_, err := verifier.VerifyApplicationID(&Request{
+				Context: &Context{System: &System{ApplicationID: `amzn1.ask.skill.allowed`}},
+				Session: &RequestSession{ApplicationID: `amzn1.ask.skill.other`},
+			})
+
+			Convey(`Then the error will be an ApplicationIDError for the rejected ID`, func() {
+				So(err, ShouldResemble, &ApplicationIDError{ApplicationID: `amzn1.ask.skill.other`})
+			})
+		})
+
+		Convey(`When I verify a request without an application ID`, func() {
+			_, err := verifier.VerifyApplicationID(&Request{})
+
+			Convey(`Then the error will be an ApplicationIDError`, func() {
+				So(err, ShouldResemble, &ApplicationIDError{})
+			})
+		})
+	})
+
+	Convey(`Given I have a Verifier not configured with application IDs`, t, func() {
+		verifier := NewVerifier()
+
+		Convey(`When I verify a request for any application ID`, func() {
+			applicationID, err := verifier.VerifyApplicationID(&Request{
+				Context: &Context{System: &System{ApplicationID: `amzn1.ask.skill.any`}},
+			})
+
+			Convey(`Then the error will be nil`, func() {
+				So(err, ShouldBeNil)
+			})
+
+			Convey(`Then the application ID will be returned`, func() {
+				So(applicationID, ShouldEqual, `amzn1.ask.skill.any`)
+			})
+		})
+
+		Convey(`When I verify a request whose context and session application IDs differ`, func() {
+			_, err := verifier.VerifyApplicationID(&Request{
+				Context: &Context{System: &System{ApplicationID: `amzn1.ask.skill.any`}},
+				Session: &RequestSession{ApplicationID: `amzn1.ask.skill.other`},
+			})
+
+			Convey(`Then the error will be an ApplicationIDError for the session application ID`, func() {
+				So(err, ShouldResemble, &ApplicationIDError{ApplicationID: `amzn1.ask.skill.other`})
+			})
+		})
+
+		Convey(`When I verify a request without an application ID`, func() {
+			_, err := verifier.VerifyApplicationID(&Request{})
+
+			Convey(`Then the error will be an ApplicationIDError`, func() {
+				So(err, ShouldResemble, &ApplicationIDError{})
+			})
+		})
+	})
+}
+
+func TestVerifier_MiddlewareApplicationID(t *testing.T) {
+	now := time.Date(2017, 8, 1, 15, 3, 44, 0, time.UTC)
+	body := []byte(`{"version":"1.0","context":{"System":{"application":{"applicationId":"amzn1.ask.skill.allowed"}}},` +
+		`"request":{"type":"LaunchRequest","requestId":"1","timestamp":"2017-08-01T15:03:44Z"}}`)
+
+	Convey(`Given I have a signing certificate chain`, t, func() {
+		chain := newTestCertificateChain(now.Add(-1*time.Hour), now.Add(time.Hour), validAlternativeName)
+		options := []VerifierOption{
+			WithClock(func() time.Time { return now }),
+			WithHTTPClient(&http.Client{Transport: &testCertificateTransport{pem: chain.pem}}),
+			WithRootCertificates(chain.roots),
+			WithCertificateCache(nil),
+		}
+
+		Convey(`And I have an endpoint wrapped by a Verifier allowing the requested application ID`, func() {
+			verifier := NewVerifier(append(options, WithApplicationIDs(`amzn1.ask.skill.other`, `amzn1.ask.skill.allowed`))...)
+
+			var applicationID string
+			var found bool
+			endpoint := verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				applicationID, found = ApplicationIDFromContext(req.Context())
+			}))
+
+			Convey(`When I make a signed request`, func() {
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, body))
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the matched application ID will be on the request context`, func() {
+					So(found, ShouldBeTrue)
+					So(applicationID, ShouldEqual, `amzn1.ask.skill.allowed`)
+				})
+			})
+		})
+
+		Convey(`And I have an endpoint wrapped by a Verifier not allowing the requested application ID`, func() {
+			verifier := NewVerifier(append(options, WithApplicationIDs(`amzn1.ask.skill.other`))...)
+
+			endpointCalled := false
+			endpoint := verifier.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				endpointCalled = true
+			}))
+
+			Convey(`When I make a signed request`, func() {
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, body))
+
+				Convey(`Then the status code will be StatusForbidden`, func() {
+					So(response.Code, ShouldEqual, http.StatusForbidden)
+				})
+
+				Convey(`Then the endpoint will not have been called`, func() {
+					So(endpointCalled, ShouldBeFalse)
+				})
+			})
+		})
+
+		Convey(`And I have an endpoint wrapped by a Verifier configured with no application IDs`, func() {
+			verifier := NewVerifier(append(options, WithApplicationIDs())...)
+
+			endpointCalled := false
+			endpoint := verifier.Middleware(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				endpointCalled = true
+			}))
+
+			Convey(`When I make a signed request`, func() {
+				response := httptest.NewRecorder()
+				endpoint.ServeHTTP(response, testSignedRequest(chain, crypto.SHA1, body))
+
+				Convey(`Then the status code will be StatusOK`, func() {
+					So(response.Code, ShouldEqual, http.StatusOK)
+				})
+
+				Convey(`Then the endpoint will have been called`, func() {
+					So(endpointCalled, ShouldBeTrue)
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,93 @@
+package alexa
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// VideoAppLaunchDirectiveType sends a video to be played on a device which supports the VideoApp interface
+	VideoAppLaunchDirectiveType DirectiveType = `VideoApp.Launch`
+)
+
+// VideoAppLaunchDirective sends a video to be played on a device which supports the VideoApp interface. Playback is
+// handled by the device, and the skill does not receive requests as the video plays.
+//
+// A response including the directive cannot include a reprompt or set shouldEndSession, and should only be returned
+// when the device supports VideoApp, see ValidateVideoAppResponse.
+type VideoAppLaunchDirective struct {
+	// VideoItem is the video to play
+	VideoItem *VideoItem `json:"videoItem"`
+}
+
+// NewVideoAppLaunchDirective returns a VideoAppLaunchDirective playing the video at source, displaying title and
+// subtitle while the video loads. title and subtitle are optional
+func NewVideoAppLaunchDirective(source, title, subtitle string) *VideoAppLaunchDirective {
+	item := &VideoItem{Source: source}
+	if title != "" || subtitle != "" {
+		item.Metadata = &VideoItemMetadata{Title: title, Subtitle: subtitle}
+	}
+	return &VideoAppLaunchDirective{VideoItem: item}
+}
+
+// GetType returns the VideoAppLaunchDirectiveType
+func (directive *VideoAppLaunchDirective) GetType() DirectiveType {
+	return VideoAppLaunchDirectiveType
+}
+
+// MarshalJSON implements the json.Marshaler interface for the VideoAppLaunchDirective type, setting the type field
+func (directive *VideoAppLaunchDirective) MarshalJSON() ([]byte, error) {
+	type Alias VideoAppLaunchDirective
+	return json.Marshal(&struct {
+		Type DirectiveType `json:"type"`
+		*Alias
+	}{
+		Type:  directive.GetType(),
+		Alias: (*Alias)(directive),
+	})
+}
+
+// VideoItem is a video played by a VideoAppLaunchDirective
+type VideoItem struct {
+	// Source is the HTTPS URL of the video. Supported formats include MPEG-4 and HLS
+	Source string `json:"source"`
+
+	// Metadata is displayed while the video loads. It is optional
+	Metadata *VideoItemMetadata `json:"metadata,omitempty"`
+}
+
+// VideoItemMetadata is displayed while a VideoItem loads
+type VideoItemMetadata struct {
+	Title    string `json:"title,omitempty"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+// ValidateVideoAppResponse checks that a response including a VideoAppLaunchDirective can be sent to device. The
+// device must support the VideoApp interface, and the response cannot include a reprompt or end the session. A
+// *ResponseError describing the first invalid field is returned if the response is not valid. A response without a
+// VideoAppLaunchDirective is always valid.
+func ValidateVideoAppResponse(response *Response, device *Device) error {
+	if response == nil || response.Response == nil {
+		return nil
+	}
+
+	data := response.Response
+	for i, directive := range data.Directives {
+		if _, ok := directive.(*VideoAppLaunchDirective); !ok {
+			continue
+		}
+		if !device.HasVideoAppSupport() {
+			return &ResponseError{
+				Field:  fmt.Sprintf("response.directives[%d]", i),
+				Reason: "VideoApp.Launch directive not supported by the device",
+			}
+		}
+		if data.ShouldEndSession != nil {
+			return &ResponseError{Field: "response.shouldEndSession", Reason: "not permitted with a VideoApp.Launch directive"}
+		}
+		if data.Reprompt != nil {
+			return &ResponseError{Field: "response.reprompt", Reason: "not permitted with a VideoApp.Launch directive"}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package alexa
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVideoAppLaunchDirectiveMarshal(t *testing.T) {
+	Convey(`When I marshal a VideoApp.Launch directive with metadata to JSON`, t, func() {
+		data, err := json.Marshal(NewVideoAppLaunchDirective(`https://example.com/video.mp4`, `Pad Thai`, `Episode 1`))
+
+		Convey(`Then the video item and metadata will be set`, func() {
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `{"type":"VideoApp.Launch","videoItem":{"source":"https://example.com/video.mp4",`+
+				`"metadata":{"title":"Pad Thai","subtitle":"Episode 1"}}}`)
+		})
+	})
+
+	Convey(`When I marshal a VideoApp.Launch directive without metadata to JSON`, t, func() {
+		data, err := json.Marshal(NewVideoAppLaunchDirective(`https://example.com/video.mp4`, ``, ``))
+
+		Convey(`Then the metadata will be omitted`, func() {
+			So(err, ShouldBeNil)
+			So(string(data), ShouldEqual, `{"type":"VideoApp.Launch","videoItem":{"source":"https://example.com/video.mp4"}}`)
+		})
+	})
+}
+
+func TestValidateVideoAppResponse(t *testing.T) {
+	videoDevice := &Device{SupportedInterfaces: map[SupportedInterfaces]interface{}{VideoAppSupported: map[string]interface{}{}}}
+	launch := NewVideoAppLaunchDirective(`https://example.com/video.mp4`, ``, ``)
+
+	Convey(`Given a response launching a video`, t, func() {
+		response := &Response{Response: &ResponseData{
+			OutputSpeech: PlainSpeech(`Here is your video`),
+			Directives:   []Directive{launch},
+		}}
+
+		Convey(`When it is sent to a device supporting VideoApp`, func() {
+			err := ValidateVideoAppResponse(response, videoDevice)
+
+			Convey(`Then it will be valid`, func() {
+				So(err, ShouldBeNil)
+			})
+		})
+
+		Convey(`When it is sent to a device without VideoApp support`, func() {
+			err := ValidateVideoAppResponse(response, &Device{})
+
+			Convey(`Then the directive will be reported`, func() {
+				So(err, ShouldResemble, &ResponseError{
+					Field:  `response.directives[0]`,
+					Reason: `VideoApp.Launch directive not supported by the device`,
+				})
+			})
+		})
+
+		Convey(`When the response sets shouldEndSession`, func() {
+			response.Response.ShouldEndSession = Bool(false)
+			err := ValidateVideoAppResponse(response, videoDevice)
+
+			Convey(`Then shouldEndSession will be reported`, func() {
+				So(err, ShouldHaveSameTypeAs, &ResponseError{})
+				So(err.(*ResponseError).Field, ShouldEqual, `response.shouldEndSession`)
+			})
+		})
+
+		Convey(`When the response includes a reprompt`, func() {
+			response.Response.Reprompt = PlainSpeech(`Are you still there?`)
+			err := ValidateVideoAppResponse(response, videoDevice)
+
+			Convey(`Then the reprompt will be reported`, func() {
+				So(err, ShouldHaveSameTypeAs, &ResponseError{})
+				So(err.(*ResponseError).Field, ShouldEqual, `response.reprompt`)
+			})
+		})
+	})
+
+	Convey(`Given a response without a video`, t, func() {
+		response := &Response{Response: &ResponseData{ShouldEndSession: Bool(true)}}
+
+		Convey(`Then it will be valid for any device`, func() {
+			So(ValidateVideoAppResponse(response, nil), ShouldBeNil)
+		})
+	})
+}
+
+func TestSkill_VideoApp(t *testing.T) {
+	Convey(`Given I have a Skill launching a video`, t, func() {
+		skill := NewSkill()
+		skill.HandleRequestFunc(LaunchRequestType, func(context.Context, *Request) (*Response, error) {
+			return &Response{Response: &ResponseData{
+				Directives: []Directive{NewVideoAppLaunchDirective(`https://example.com/video.mp4`, ``, ``)},
+			}}, nil
+		})
+
+		Convey(`When the request is from a device without VideoApp support`, func() {
+			request := &Request{Context: &Context{System: &System{Device: &Device{}}}, Request: &LaunchRequest{}}
+			response, err := skill.ServeAlexa(context.Background(), request)
+
+			Convey(`Then the response will be refused`, func() {
+				So(response, ShouldBeNil)
+				So(err, ShouldHaveSameTypeAs, &ResponseError{})
+			})
+		})
+	})
+}